@@ -0,0 +1,56 @@
+package cachetest
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	cache "github.com/grrrance/lfu-in-memory"
+)
+
+const hotKeys = 1024
+
+func fill(factory Factory) (cache.InMemoryLFU, []string) {
+	c := factory(hotKeys, time.Hour)
+
+	keys := make([]string, hotKeys)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+		c.Set(keys[i], i, 0)
+	}
+
+	return c, keys
+}
+
+// RunZeroAllocGet fails t if a Get hit on a string key allocates. Caches
+// built by factory are expected to accept at least 1024 entries.
+func RunZeroAllocGet(t *testing.T, factory Factory) {
+	c, keys := fill(factory)
+
+	var i int
+	allocs := testing.AllocsPerRun(10*hotKeys, func() {
+		c.Get(keys[i%hotKeys])
+		i++
+	})
+	if allocs != 0 {
+		t.Fatalf("Get hit allocates %.2f times per call, want 0", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(10*hotKeys, func() {
+		c.Get(keys[0])
+	})
+	if allocs != 0 {
+		t.Fatalf("repeated Get hit on one key allocates %.2f times per call, want 0", allocs)
+	}
+}
+
+// BenchmarkGetHit measures Get hits spread evenly over 1024 keys.
+func BenchmarkGetHit(b *testing.B, factory Factory) {
+	c, keys := fill(factory)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Get(keys[i%hotKeys])
+	}
+}
@@ -0,0 +1,224 @@
+package cachetest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+// Clock is a manual lfu.TimerClock. Time only moves when Advance or
+// AdvanceTime is called.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	elapsed time.Duration
+	tickers map[*ticker]struct{}
+	timers  map[*timer]struct{}
+}
+
+// NewClock returns a Clock reading start.
+func NewClock(start time.Time) *Clock {
+	return &Clock{
+		now:     start,
+		tickers: make(map[*ticker]struct{}),
+		timers:  make(map[*timer]struct{}),
+	}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *Clock) Monotonic() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.elapsed
+}
+
+func (c *Clock) NewTicker(d time.Duration) lfu.Ticker {
+	if d <= 0 {
+		panic("cachetest: non-positive ticker period")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &ticker{
+		clock:   c,
+		period:  d,
+		next:    c.elapsed + d,
+		ch:      make(chan time.Time),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	c.tickers[t] = struct{}{}
+
+	return t
+}
+
+func (c *Clock) NewTimer(d time.Duration) lfu.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &timer{clock: c, at: c.elapsed + d, ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.timers[t] = struct{}{}
+
+	return t
+}
+
+// Advance moves the clock forward by d, stopping at every tick and timer
+// that falls due on the way. Each tick is delivered and its work waited for
+// before the clock moves on, so everything due has run when Advance
+// returns. Work that itself waits on the clock, such as webhook retry
+// backoff, needs another goroutine to advance it.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.elapsed + d
+	for {
+		t, tm := c.nextDue(target)
+		if t == nil && tm == nil {
+			c.now = c.now.Add(target - c.elapsed)
+			c.elapsed = target
+			c.mu.Unlock()
+			return
+		}
+
+		if t != nil {
+			c.moveTo(t.next)
+			t.next += t.period
+			now := c.now
+			c.mu.Unlock()
+			t.fire(now)
+		} else {
+			c.moveTo(tm.at)
+			delete(c.timers, tm)
+			tm.ch <- c.now
+			c.mu.Unlock()
+		}
+
+		c.mu.Lock()
+	}
+}
+
+// Step moves the wall clock by d, which may be negative, as an NTP step
+// would: the monotonic reading stays put and no ticker or timer fires.
+func (c *Clock) Step(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// nextDue returns the ticker or timer due earliest, but not after target.
+// Ties go to the timer.
+func (c *Clock) nextDue(target time.Duration) (*ticker, *timer) {
+	var (
+		dueTicker *ticker
+		dueTimer  *timer
+		at        = target + 1
+	)
+
+	for tm := range c.timers {
+		if tm.at < at {
+			dueTimer, at = tm, tm.at
+		}
+	}
+	for t := range c.tickers {
+		if t.next < at {
+			dueTicker, dueTimer, at = t, nil, t.next
+		}
+	}
+
+	return dueTicker, dueTimer
+}
+
+func (c *Clock) moveTo(elapsed time.Duration) {
+	c.now = c.now.Add(elapsed - c.elapsed)
+	c.elapsed = elapsed
+}
+
+type ticker struct {
+	clock    *Clock
+	period   time.Duration
+	next     time.Duration
+	ch       chan time.Time
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (t *ticker) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *ticker) Done() {
+	select {
+	case t.done <- struct{}{}:
+	case <-t.stopped:
+	}
+}
+
+func (t *ticker) Stop() {
+	t.stopOnce.Do(func() {
+		t.clock.mu.Lock()
+		delete(t.clock.tickers, t)
+		t.clock.mu.Unlock()
+		close(t.stopped)
+	})
+}
+
+// fire hands now to the ticker's reader and waits for it to call Done. It
+// gives up if the ticker is stopped first.
+func (t *ticker) fire(now time.Time) {
+	select {
+	case t.ch <- now:
+	case <-t.stopped:
+		return
+	}
+
+	select {
+	case <-t.done:
+	case <-t.stopped:
+	}
+}
+
+type timer struct {
+	clock *Clock
+	at    time.Duration
+	ch    chan time.Time
+}
+
+func (t *timer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *timer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	_, pending := t.clock.timers[t]
+	delete(t.clock.timers, t)
+
+	return pending
+}
+
+// AdvanceTime advances the Clock that c was built with by d, as
+// Clock.Advance does. It panics if c was not built
+// WithClock(cachetest.NewClock(...)).
+func AdvanceTime(c interface{ Clock() lfu.Clock }, d time.Duration) {
+	clock, ok := c.Clock().(*Clock)
+	if !ok {
+		panic("cachetest: cache does not use a cachetest.Clock")
+	}
+
+	clock.Advance(d)
+}
@@ -0,0 +1,186 @@
+// Package cachetest holds behavioral checks shared by every InMemoryLFU
+// implementation.
+package cachetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	cache "github.com/grrrance/lfu-in-memory"
+)
+
+// Factory returns a new, empty cache holding at most capacity entries whose
+// writes with a non-positive duration expire after defaultTTL.
+type Factory func(capacity int, defaultTTL time.Duration) cache.InMemoryLFU
+
+// RunLFUConformance runs the LFU conformance suite against the caches built
+// by factory, one subtest per behavior.
+func RunLFUConformance(t *testing.T, factory Factory) {
+	t.Run("SetGet", func(t *testing.T) { testSetGet(t, factory) })
+	t.Run("Overwrite", func(t *testing.T) { testOverwrite(t, factory) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, factory) })
+	t.Run("EvictsLeastFrequent", func(t *testing.T) { testEvictsLeastFrequent(t, factory) })
+	t.Run("OverwriteKeepsFrequency", func(t *testing.T) { testOverwriteKeepsFrequency(t, factory) })
+	t.Run("Expiration", func(t *testing.T) { testExpiration(t, factory) })
+	t.Run("DefaultTTL", func(t *testing.T) { testDefaultTTL(t, factory) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, factory) })
+	t.Run("Concurrency", func(t *testing.T) { testConcurrency(t, factory) })
+}
+
+func mustGet(t *testing.T, c cache.InMemoryLFU, key string, want interface{}) {
+	t.Helper()
+
+	got, ok := c.Get(key)
+	if !ok {
+		t.Fatalf("Get(%q): miss, want %v", key, want)
+	}
+	if got != want {
+		t.Fatalf("Get(%q) = %v, want %v", key, got, want)
+	}
+}
+
+func mustMiss(t *testing.T, c cache.InMemoryLFU, key string) {
+	t.Helper()
+
+	if got, ok := c.Get(key); ok {
+		t.Fatalf("Get(%q) = %v, want miss", key, got)
+	}
+}
+
+func testSetGet(t *testing.T, factory Factory) {
+	c := factory(4, time.Hour)
+
+	c.Set("a", 1, 0)
+	c.Set("b", "two", time.Minute)
+
+	mustGet(t, c, "a", 1)
+	mustGet(t, c, "b", "two")
+	mustMiss(t, c, "c")
+}
+
+func testOverwrite(t *testing.T, factory Factory) {
+	c := factory(4, time.Hour)
+
+	c.Set("a", 1, 0)
+	c.Set("a", 2, 0)
+
+	mustGet(t, c, "a", 2)
+}
+
+func testDelete(t *testing.T, factory Factory) {
+	c := factory(4, time.Hour)
+
+	c.Set("a", 1, 0)
+	if err := c.Delete("a"); err != nil {
+		t.Fatalf("Delete(existing) = %v", err)
+	}
+	mustMiss(t, c, "a")
+
+	if err := c.Delete("a"); err == nil {
+		t.Fatal("Delete(missing) succeeded, want error")
+	}
+}
+
+func testEvictsLeastFrequent(t *testing.T, factory Factory) {
+	c := factory(3, time.Hour)
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Set("c", 3, 0)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+
+	c.Set("d", 4, 0)
+
+	mustMiss(t, c, "c")
+	mustGet(t, c, "a", 1)
+	mustGet(t, c, "b", 2)
+	mustGet(t, c, "d", 4)
+}
+
+func testOverwriteKeepsFrequency(t *testing.T, factory Factory) {
+	c := factory(2, time.Hour)
+
+	c.Set("a", 1, 0)
+	c.Get("a")
+	c.Get("a")
+	c.Set("b", 2, 0)
+	c.Set("a", 10, 0)
+
+	c.Set("c", 3, 0)
+
+	mustMiss(t, c, "b")
+	mustGet(t, c, "a", 10)
+	mustGet(t, c, "c", 3)
+}
+
+func testExpiration(t *testing.T, factory Factory) {
+	c := factory(4, time.Hour)
+
+	c.Set("short", 1, 10*time.Millisecond)
+	c.Set("long", 2, time.Hour)
+	time.Sleep(30 * time.Millisecond)
+
+	mustMiss(t, c, "short")
+	mustGet(t, c, "long", 2)
+}
+
+func testDefaultTTL(t *testing.T, factory Factory) {
+	c := factory(4, 10*time.Millisecond)
+
+	c.Set("a", 1, 0)
+	mustGet(t, c, "a", 1)
+	time.Sleep(30 * time.Millisecond)
+
+	mustMiss(t, c, "a")
+}
+
+func testUpdate(t *testing.T, factory Factory) {
+	type counter struct{ n int }
+
+	c := factory(4, time.Hour)
+
+	even, odd := &counter{n: 2}, &counter{n: 3}
+	c.Set("even", even, 0)
+	c.Set("odd", odd, 0)
+
+	c.Update(
+		func(v interface{}) bool { return v.(*counter).n%2 == 0 },
+		func(v interface{}) { v.(*counter).n *= 10 },
+		time.Hour,
+	)
+
+	if even.n != 20 || odd.n != 3 {
+		t.Fatalf("after Update even = %d, odd = %d, want 20 and 3", even.n, odd.n)
+	}
+	mustGet(t, c, "even", even)
+}
+
+func testConcurrency(t *testing.T, factory Factory) {
+	const workers, ops = 8, 500
+
+	c := factory(64, time.Hour)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < ops; i++ {
+				key := fmt.Sprintf("k%d", (w*ops+i)%128)
+				c.Set(key, i, 0)
+				c.Get(key)
+				if i%7 == 0 {
+					_ = c.Delete(key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	c.Set("final", "v", 0)
+	mustGet(t, c, "final", "v")
+}
@@ -0,0 +1,54 @@
+package lfu
+
+const activeExpiryRounds = 16
+
+// WithActiveExpiry replaces the full scan of every maintenance sweep with
+// sampling: the sweep inspects up to samples entries in map iteration order,
+// removes the expired ones and repeats while at least threshold of the
+// sampled entries were expired, up to a fixed number of rounds. Sweep cost
+// then stays bounded regardless of the cache size, at the price of expired
+// entries lingering until sampled or evicted. The option is ignored unless
+// samples is positive and threshold lies in (0, 1].
+func WithActiveExpiry(samples int, threshold float64) Option {
+	return func(c *settings) {
+		if samples > 0 && threshold > 0 && threshold <= 1 {
+			c.activeSamples = samples
+			c.activeThreshold = threshold
+		}
+	}
+}
+
+func (c *Cache[K, V]) sampleExpired() (removed, reclaimed int) {
+	now := c.clock.Now()
+
+	for round := 0; round < activeExpiryRounds; round++ {
+		var sampled, expired int
+		for key, item := range c.items {
+			if sampled == c.activeSamples {
+				break
+			}
+			sampled++
+
+			reason := ReasonExpired
+			if !c.isReapable(item) {
+				if !c.isCold(item, now) {
+					continue
+				}
+				reason = ReasonCold
+			}
+
+			c.removeItem(item, key, reason)
+			expired++
+			reclaimed += item.cost()
+		}
+
+		removed += expired
+		if sampled == 0 || float64(expired) < c.activeThreshold*float64(sampled) {
+			break
+		}
+	}
+
+	removed += c.removeExpiredOverflow()
+
+	return removed, reclaimed
+}
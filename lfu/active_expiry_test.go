@@ -0,0 +1,39 @@
+package lfu_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestActiveExpiryIgnoresBadThreshold(t *testing.T) {
+	for _, threshold := range []float64{0, -1, 1.5} {
+		t.Run(fmt.Sprint(threshold), func(t *testing.T) {
+			c, clock := newClockCache(t, 200, time.Minute, lfu.WithActiveExpiry(4, threshold))
+
+			for i := 0; i < 200; i++ {
+				c.Set(fmt.Sprint(i), i, time.Second)
+			}
+			clock.Advance(time.Minute)
+
+			if n := c.Stats().Len; n != 0 {
+				t.Fatalf("sweep left %d expired entries, want a full scan", n)
+			}
+		})
+	}
+}
+
+func TestActiveExpirySamples(t *testing.T) {
+	c, clock := newClockCache(t, 200, time.Minute, lfu.WithActiveExpiry(4, 0.5))
+
+	for i := 0; i < 200; i++ {
+		c.Set(fmt.Sprint(i), i, time.Second)
+	}
+	clock.Advance(time.Minute)
+
+	if n := c.Stats().Len; n == 0 || n == 200 {
+		t.Fatalf("sampling sweep left %d of 200 expired entries", n)
+	}
+}
@@ -0,0 +1,40 @@
+package lfu
+
+import "time"
+
+// Add is Set that only stores value when key is absent or expired, and
+// otherwise returns ErrKeyExists without touching the existing entry.
+func (c *Cache[K, V]) Add(key K, value V, duration time.Duration) error {
+	defer c.slowKeyOp("set", key, c.slowStart())
+
+	if err := c.writable(); err != nil {
+		return err
+	}
+	if c.size <= 0 {
+		return c.misuse(ErrZeroCapacity)
+	}
+	c.checkTTL(duration)
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	if item, found := c.items[key]; found && !c.isExpired(item) {
+		return ErrKeyExists
+	}
+	if c.overflow != nil {
+		if element, ok := c.overflow.entries[key]; ok && !c.isExpired(c.overflow.item(element)) {
+			return ErrKeyExists
+		}
+	}
+
+	switch c.put("", key, value, duration) {
+	case SetTooLarge:
+		return ErrItemTooLarge
+	case SetNotAdmitted:
+		return ErrNotAdmitted
+	}
+
+	return nil
+}
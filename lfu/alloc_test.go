@@ -0,0 +1,35 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cache "github.com/grrrance/lfu-in-memory"
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func inMemoryFactory(tb testing.TB, opts ...lfu.Option) cachetest.Factory {
+	return func(capacity int, defaultTTL time.Duration) cache.InMemoryLFU {
+		c := lfu.NewInMemoryCache(capacity, defaultTTL, 0, opts...)
+		tb.Cleanup(func() { c.Shutdown(context.Background()) })
+		return c
+	}
+}
+
+func TestGetHitDoesNotAllocate(t *testing.T) {
+	cachetest.RunZeroAllocGet(t, inMemoryFactory(t))
+}
+
+func TestReadMostlyGetHitDoesNotAllocate(t *testing.T) {
+	cachetest.RunZeroAllocGet(t, inMemoryFactory(t, lfu.WithReadMostly()))
+}
+
+func BenchmarkGetHit(b *testing.B) {
+	cachetest.BenchmarkGetHit(b, inMemoryFactory(b))
+}
+
+func BenchmarkReadMostlyGetHit(b *testing.B) {
+	cachetest.BenchmarkGetHit(b, inMemoryFactory(b, lfu.WithReadMostly()))
+}
@@ -0,0 +1,130 @@
+package lfu
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	auditSet    = "set"
+	auditDelete = "delete"
+	auditUpdate = "update"
+	auditTouch  = "touch"
+)
+
+// AuditRecord describes one mutation made by a caller. Keys removed by
+// Flush, InvalidateSubtree or a namespace refresh are recorded as deletes,
+// and a Rename as a delete of the old key and a set of the new one.
+// Evictions and expirations are not audited. Principal is empty for calls made without a
+// context carrying one. Keys that are not strings are recorded as formatted
+// by fmt.Sprint.
+type AuditRecord struct {
+	Time      time.Time
+	Principal string
+	Op        string
+	Key       string
+	Value     interface{}
+}
+
+// AuditConfig configures the audit log. Size bounds the number of records
+// kept; older records are discarded first. RedactKey, when set, is applied
+// to keys before they are recorded. Values are only recorded when
+// RedactValue is set, and then as returned by it.
+type AuditConfig struct {
+	Size        int
+	RedactKey   func(key string) string
+	RedactValue func(value interface{}) interface{}
+}
+
+type auditLog struct {
+	config  AuditConfig
+	records []AuditRecord
+	next    int
+}
+
+type principalKey struct{}
+
+func WithAudit(config AuditConfig) Option {
+	return func(c *settings) {
+		if config.Size > 0 {
+			c.auditLog = &auditLog{
+				config:  config,
+				records: make([]AuditRecord, 0, config.Size),
+			}
+		}
+	}
+}
+
+// WithPrincipal returns a context identifying the service or user on whose
+// behalf SetContext and DeleteContext mutate the cache.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFrom(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
+
+func (c *Cache[K, V]) SetContext(ctx context.Context, key K, value V, duration time.Duration) error {
+	return c.set(PrincipalFrom(ctx), key, value, duration, nil, "")
+}
+
+func (c *Cache[K, V]) DeleteContext(ctx context.Context, key K) error {
+	return c.delete(PrincipalFrom(ctx), key)
+}
+
+func (c *Cache[K, V]) audit(principal, op string, key K, value *V) {
+	if c.auditLog == nil {
+		return
+	}
+
+	config := c.auditLog.config
+	record := AuditRecord{
+		Time:      c.clock.Now(),
+		Principal: principal,
+		Op:        op,
+		Key:       keyText(key),
+	}
+	if config.RedactKey != nil {
+		record.Key = config.RedactKey(record.Key)
+	}
+	if config.RedactValue != nil && value != nil {
+		record.Value = config.RedactValue(*value)
+	}
+
+	if len(c.auditLog.records) < config.Size {
+		c.auditLog.records = append(c.auditLog.records, record)
+		return
+	}
+	c.auditLog.records[c.auditLog.next] = record
+	c.auditLog.next = (c.auditLog.next + 1) % config.Size
+}
+
+// AuditTrail returns the retained audit records for key, oldest first. The
+// key is redacted the same way as recorded keys before matching. An empty
+// key returns every retained record.
+func (c *Cache[K, V]) AuditTrail(key string) []AuditRecord {
+	if c.usable() != nil || c.auditLog == nil {
+		return nil
+	}
+
+	c.lock(opStats)
+	defer c.unlock()
+
+	if key != "" && c.auditLog.config.RedactKey != nil {
+		key = c.auditLog.config.RedactKey(key)
+	}
+
+	records := c.auditLog.records
+	ordered := append(records[c.auditLog.next:len(records):len(records)], records[:c.auditLog.next]...)
+
+	var trail []AuditRecord
+	for _, record := range ordered {
+		if key == "" || record.Key == key {
+			trail = append(trail, record)
+		}
+	}
+
+	return trail
+}
@@ -0,0 +1,84 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestAuditCoversEveryMutation(t *testing.T) {
+	key, fresh := lfu.Key("ns", "k"), lfu.Key("ns", "fresh")
+	always := func(interface{}) bool { return true }
+
+	tests := []struct {
+		name   string
+		key    string
+		op     string
+		mutate func(c *lfu.InMemoryCache)
+	}{
+		{"Set", key, "set", func(c *lfu.InMemoryCache) { c.Set(key, int64(2), time.Hour) }},
+		{"SetContext", key, "set", func(c *lfu.InMemoryCache) {
+			c.SetContext(context.Background(), key, int64(2), time.Hour)
+		}},
+		{"SetOwned", key, "set", func(c *lfu.InMemoryCache) { c.SetOwned(key, int64(2), time.Hour, "a") }},
+		{"SetWithPriority", key, "set", func(c *lfu.InMemoryCache) { c.SetWithPriority(key, int64(2), time.Hour, 1) }},
+		{"Replace", key, "set", func(c *lfu.InMemoryCache) { c.Replace(key, int64(2), time.Hour) }},
+		{"Add", fresh, "set", func(c *lfu.InMemoryCache) { c.Add(fresh, int64(2), time.Hour) }},
+		{"GetOrSet", fresh, "set", func(c *lfu.InMemoryCache) { c.GetOrSet(fresh, int64(2), time.Hour) }},
+		{"SetMany", key, "set", func(c *lfu.InMemoryCache) {
+			c.SetMany([]lfu.Entry[string, interface{}]{{Key: key, Value: int64(2)}})
+		}},
+		{"MSet", key, "set", func(c *lfu.InMemoryCache) {
+			c.MSet(map[string]lfu.ValueWithTTL[interface{}]{key: {Value: int64(2)}})
+		}},
+		{"SetField", fresh, "set", func(c *lfu.InMemoryCache) { c.SetField(fresh, "f", 1, time.Hour) }},
+		{"Increment", key, "set", func(c *lfu.InMemoryCache) { c.Increment(key, 1) }},
+		{"IncrementAbsent", fresh, "set", func(c *lfu.InMemoryCache) { c.Increment(fresh, 1) }},
+		{"Update", key, "update", func(c *lfu.InMemoryCache) { c.Update(always, func(interface{}) {}, time.Hour) }},
+		{"TouchMany", key, "touch", func(c *lfu.InMemoryCache) { c.TouchMany([]string{key}, time.Hour) }},
+		{"Delete", key, "delete", func(c *lfu.InMemoryCache) { c.Delete(key) }},
+		{"DeleteGet", key, "delete", func(c *lfu.InMemoryCache) { c.DeleteGet(key) }},
+		{"DeleteContext", key, "delete", func(c *lfu.InMemoryCache) { c.DeleteContext(context.Background(), key) }},
+		{"InvalidateSubtree", key, "delete", func(c *lfu.InMemoryCache) { c.InvalidateSubtree(key) }},
+		{"Flush", key, "delete", func(c *lfu.InMemoryCache) { c.Flush() }},
+		{"FlushNamespace", key, "delete", func(c *lfu.InMemoryCache) { c.FlushNamespace("ns") }},
+		{"RenameFrom", key, "delete", func(c *lfu.InMemoryCache) { c.Rename(key, fresh, false) }},
+		{"RenameTo", fresh, "set", func(c *lfu.InMemoryCache) { c.Rename(key, fresh, false) }},
+		{"SetPermanent", fresh, "set", func(c *lfu.InMemoryCache) { c.SetPermanent(fresh, int64(2)) }},
+		{"DeletePermanent", fresh, "delete", func(c *lfu.InMemoryCache) {
+			c.SetPermanent(fresh, int64(2))
+			c.DeletePermanent(fresh)
+		}},
+		{"NamespaceRefresh", key, "delete", func(c *lfu.InMemoryCache) {
+			empty := func(context.Context, string) (map[string]interface{}, time.Duration, error) {
+				return nil, 0, nil
+			}
+			stop := c.ScheduleNamespaceRefresh("ns", time.Minute, empty)
+			defer stop()
+			cachetest.AdvanceTime(c, time.Minute)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := cachetest.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			c := lfu.NewInMemoryCache(8, time.Hour, 0, lfu.WithClock(clock), lfu.WithAudit(lfu.AuditConfig{Size: 64}))
+			t.Cleanup(func() { c.Shutdown(context.Background()) })
+			c.Set(key, int64(1), time.Hour)
+
+			before := len(c.AuditTrail(tt.key))
+			tt.mutate(c)
+
+			trail := c.AuditTrail(tt.key)
+			if len(trail) == before {
+				t.Fatalf("%s wrote no audit record for %q", tt.name, tt.key)
+			}
+			if op := trail[len(trail)-1].Op; op != tt.op {
+				t.Fatalf("%s recorded op %q, want %q", tt.name, op, tt.op)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package lfu
+
+// bucket holds the items sharing one frequency in a doubly linked list,
+// ordered by when they entered the bucket, oldest first. Every access moves
+// an item to the tail of a bucket, so this is least recently used first and
+// eviction breaks frequency ties by recency. The links live in Item itself
+// so moving an item between buckets does not allocate.
+//
+// The non-empty buckets form a second list in ascending frequency order,
+// starting at Cache.lowest, so the next eviction victim and the bucket an
+// accessed item moves to are both found in constant time.
+type bucket[K comparable, V any] struct {
+	head  *Item[K, V]
+	tail  *Item[K, V]
+	size  int
+	level uint64
+	prev  *bucket[K, V]
+	next  *bucket[K, V]
+}
+
+func (b *bucket[K, V]) pushBack(item *Item[K, V]) {
+	item.prev, item.next = b.tail, nil
+	if b.tail != nil {
+		b.tail.next = item
+	} else {
+		b.head = item
+	}
+	b.tail = item
+	b.size++
+}
+
+func (b *bucket[K, V]) remove(item *Item[K, V]) {
+	if item.prev != nil {
+		item.prev.next = item.next
+	} else {
+		b.head = item.next
+	}
+	if item.next != nil {
+		item.next.prev = item.prev
+	} else {
+		b.tail = item.prev
+	}
+	item.prev, item.next = nil, nil
+	b.size--
+}
+
+// place puts item in the bucket for its current frequency, creating the
+// bucket if needed. The search starts at from, which must not be past that
+// bucket, or at the lowest bucket when from is nil.
+func (c *Cache[K, V]) place(item *Item[K, V], from *bucket[K, V]) {
+	level := c.level(item.Frequency)
+
+	var prev *bucket[K, V]
+	b := c.lowest
+	if from != nil {
+		prev, b = from.prev, from
+	}
+	for b != nil && b.level < level {
+		prev, b = b, b.next
+	}
+
+	if b == nil || b.level != level {
+		b = c.linkBucket(prev, level)
+	}
+	b.pushBack(item)
+	item.bucket = b
+}
+
+// unplace takes item out of its bucket, dropping the bucket once empty.
+func (c *Cache[K, V]) unplace(item *Item[K, V]) {
+	b := item.bucket
+	if b == nil {
+		return
+	}
+
+	b.remove(item)
+	item.bucket = nil
+	if b.size == 0 {
+		c.unlinkBucket(b)
+	}
+}
+
+// move re-places item after its frequency changed from that of its current
+// bucket. The old bucket stays linked until item has found its new one, so
+// the search can start there.
+func (c *Cache[K, V]) move(item *Item[K, V]) {
+	old := item.bucket
+	old.remove(item)
+	c.place(item, old)
+	if old.size == 0 {
+		c.unlinkBucket(old)
+	}
+}
+
+func (c *Cache[K, V]) linkBucket(prev *bucket[K, V], level uint64) *bucket[K, V] {
+	var b *bucket[K, V]
+	if n := len(c.spareGroups); n > 0 {
+		b = c.spareGroups[n-1]
+		c.spareGroups = c.spareGroups[:n-1]
+	} else {
+		b = &bucket[K, V]{}
+	}
+
+	b.level, b.prev = level, prev
+	if prev != nil {
+		b.next, prev.next = prev.next, b
+	} else {
+		b.next, c.lowest = c.lowest, b
+	}
+	if b.next != nil {
+		b.next.prev = b
+	}
+
+	return b
+}
+
+func (c *Cache[K, V]) unlinkBucket(b *bucket[K, V]) {
+	if b.prev != nil {
+		b.prev.next = b.next
+	} else {
+		c.lowest = b.next
+	}
+	if b.next != nil {
+		b.next.prev = b.prev
+	}
+
+	*b = bucket[K, V]{}
+	if len(c.spareGroups) < spareGroupsLimit {
+		c.spareGroups = append(c.spareGroups, b)
+	}
+}
@@ -0,0 +1,122 @@
+package lfu_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func newClockCache(t testing.TB, size int, cleanup time.Duration, opts ...lfu.Option) (*lfu.Cache[string, int], *cachetest.Clock) {
+	clock := cachetest.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := lfu.NewCache[string, int](size, time.Hour, cleanup, append([]lfu.Option{lfu.WithClock(clock)}, opts...)...)
+	t.Cleanup(func() { c.Shutdown(context.Background()) })
+
+	return c, clock
+}
+
+func TestSetAtCapacityReclaimsExpiredBeforeEvicting(t *testing.T) {
+	c, clock := newClockCache(t, 3, 0)
+
+	c.Set("hot", 1, time.Second)
+	for i := 0; i < 10; i++ {
+		c.Get("hot")
+	}
+	c.Set("a", 2, time.Minute)
+	c.Set("b", 3, time.Minute)
+	clock.Advance(2 * time.Second)
+
+	if err := c.Set("c", 4, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("live key %q was evicted while an expired entry remained", key)
+		}
+	}
+	stats := c.Stats()
+	if stats.Evictions != 0 || stats.Expirations != 1 {
+		t.Errorf("evictions = %d, expirations = %d, want 0 and 1", stats.Evictions, stats.Expirations)
+	}
+}
+
+func TestSetAtCapacityEvictsLowestLiveFrequency(t *testing.T) {
+	c, _ := newClockCache(t, 2, 0)
+
+	c.Set("a", 1, time.Minute)
+	c.Get("a")
+	c.Set("b", 2, time.Minute)
+	c.Set("c", 3, time.Minute)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted as the least frequently used entry")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("a was evicted despite its higher frequency")
+	}
+}
+
+func TestSetRacingSweepNeverEvictsLive(t *testing.T) {
+	const size = 100
+	c, clock := newClockCache(t, size, time.Second)
+
+	for i := 0; i < size; i++ {
+		c.Set(fmt.Sprint("old", i), i, time.Second)
+	}
+	clock.Advance(1500 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < size; i++ {
+			c.Set(fmt.Sprint("new", i), i, time.Hour)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			clock.Advance(100 * time.Millisecond)
+		}
+	}()
+	wg.Wait()
+
+	stats := c.Stats()
+	if stats.Evictions != 0 {
+		t.Errorf("%d live entries evicted while expired ones remained", stats.Evictions)
+	}
+	if stats.Len > size {
+		t.Errorf("len = %d exceeds capacity %d", stats.Len, size)
+	}
+	for i := 0; i < size; i++ {
+		if _, ok := c.Get(fmt.Sprint("new", i)); !ok {
+			t.Fatalf("new%d missing", i)
+		}
+	}
+}
+
+func BenchmarkSetAtCapacityWithExpiry(b *testing.B) {
+	for _, size := range []int{10_000, 100_000} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			c, clock := newClockCache(b, size, 0)
+			for i := 0; i < size; i++ {
+				c.Set(fmt.Sprint(i), i, time.Duration(i+1)*time.Millisecond)
+			}
+			keys := make([]string, b.N)
+			for i := range keys {
+				keys[i] = fmt.Sprint("k", i)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				clock.Advance(time.Millisecond)
+				c.Set(keys[i], i, time.Hour)
+			}
+		})
+	}
+}
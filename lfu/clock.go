@@ -0,0 +1,132 @@
+package lfu
+
+import "time"
+
+// Clock is the cache's source of time. Now is the wall clock and Monotonic
+// is the time elapsed since an arbitrary fixed origin; unlike Now it must
+// never jump, even when the wall clock is stepped.
+type Clock interface {
+	Now() time.Time
+	Monotonic() time.Duration
+}
+
+type systemClock struct {
+	origin time.Time
+}
+
+func (s systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (s systemClock) Monotonic() time.Duration {
+	return time.Since(s.origin)
+}
+
+func WithClock(clock Clock) Option {
+	return func(c *settings) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// WithMonotonicExpiry decides expiry by the clock's monotonic reading
+// instead of comparing wall-clock times, so NTP steps or a suspended VM
+// neither expire entries early nor keep them alive past their TTL. Item
+// Expiration values are still reported in wall-clock time.
+func WithMonotonicExpiry() Option {
+	return func(c *settings) {
+		c.monotonic = true
+	}
+}
+
+func (c *Cache[K, V]) setExp(item *Item[K, V], duration time.Duration) {
+	if duration <= 0 {
+		duration = c.typeDuration(item.Value)
+	}
+
+	item.Expiration = c.clock.Now().Add(duration)
+	item.deadline = c.clock.Monotonic() + duration
+	if item.expiryIndex != 0 {
+		c.trackExpiry(item)
+	}
+}
+
+func (c *Cache[K, V]) isExpired(item *Item[K, V]) bool {
+	return c.pastDeadline(item.Expiration, item.deadline)
+}
+
+func (c *Cache[K, V]) pastDeadline(expiration time.Time, deadline time.Duration) bool {
+	if c.monotonic {
+		return c.clock.Monotonic() > deadline
+	}
+
+	return c.clock.Now().After(expiration)
+}
+
+// TimerClock is a Clock that also drives the cache's timers: the cleanup
+// loop, namespace refresh and flush schedules, webhook flushes and retry
+// backoff, and lease waits. Caches built with a plain Clock use the
+// runtime's timers.
+type TimerClock interface {
+	Clock
+	NewTicker(d time.Duration) Ticker
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker delivers periodic ticks. Done is called once the work triggered by
+// a tick has finished, which lets a test clock wait for it.
+type Ticker interface {
+	C() <-chan time.Time
+	Done()
+	Stop()
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+func (t systemTicker) Done() {}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+func (c *Cache[K, V]) newTicker(d time.Duration) Ticker {
+	if clock, ok := c.clock.(TimerClock); ok {
+		return clock.NewTicker(d)
+	}
+
+	return systemTicker{time.NewTicker(d)}
+}
+
+func (c *Cache[K, V]) newTimer(d time.Duration) Timer {
+	if clock, ok := c.clock.(TimerClock); ok {
+		return clock.NewTimer(d)
+	}
+
+	return systemTimer{time.NewTimer(d)}
+}
+
+// Clock returns the clock the cache was built with, or nil for a nil
+// cache.
+func (c *Cache[K, V]) Clock() Clock {
+	if c == nil {
+		return nil
+	}
+
+	return c.clock
+}
@@ -0,0 +1,15 @@
+package lfu_test
+
+import (
+	"testing"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestClockOnNilCache(t *testing.T) {
+	var c *lfu.InMemoryCache
+
+	if clock := c.Clock(); clock != nil {
+		t.Fatalf("Clock() = %v, want nil", clock)
+	}
+}
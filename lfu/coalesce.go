@@ -0,0 +1,143 @@
+package lfu
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchLoader loads the values of keys from the origin in one call. Keys
+// missing from the result do not exist.
+type BatchLoader[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Coalescer collects the misses of its Get calls over a short window and
+// loads them with a single BatchLoader call.
+type Coalescer[K comparable, V any] struct {
+	c      *Cache[K, V]
+	load   BatchLoader[K, V]
+	window time.Duration
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	pending *coalesced[K, V]
+}
+
+type coalesced[K comparable, V any] struct {
+	keys   []K
+	seen   map[K]struct{}
+	values map[K]V
+	err    error
+	done   chan struct{}
+}
+
+// Coalesce returns a Coalescer that loads misses with load, window after
+// the first miss of a batch, and stores the loaded values with ttl.
+func (c *Cache[K, V]) Coalesce(window, ttl time.Duration, load BatchLoader[K, V]) *Coalescer[K, V] {
+	c.checkCallbacks(load == nil)
+	c.checkTTL(ttl)
+
+	return &Coalescer[K, V]{c: c, load: load, window: window, ttl: ttl}
+}
+
+// Get returns the cached value of key or, on a miss, waits for the batch
+// the key joins to be loaded. It returns ErrKeyNotFound when the loader
+// did not return key, the loader's error when it failed, ErrLoaderPanic when
+// it panicked, or ctx's error if ctx ends first; the batch is loaded
+// regardless.
+func (b *Coalescer[K, V]) Get(ctx context.Context, key K) (V, error) {
+	var zero V
+	if err := b.c.usable(); err != nil {
+		return zero, err
+	}
+	if b.load == nil {
+		return zero, ErrNilCallback
+	}
+
+	if value, found := b.c.Get(key); found {
+		return value, nil
+	}
+
+	batch := b.join(key)
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+
+	if batch.err != nil {
+		return zero, batch.err
+	}
+	value, found := batch.values[key]
+	if !found {
+		return zero, ErrKeyNotFound
+	}
+
+	return value, nil
+}
+
+func (b *Coalescer[K, V]) join(key K) *coalesced[K, V] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch := b.pending
+	if batch == nil {
+		batch = &coalesced[K, V]{
+			seen: make(map[K]struct{}),
+			done: make(chan struct{}),
+		}
+		b.pending = batch
+
+		timer := b.c.newTimer(b.window)
+		go func() {
+			<-timer.C()
+			b.flush(batch)
+		}()
+	}
+
+	if _, dup := batch.seen[key]; !dup {
+		batch.seen[key] = struct{}{}
+		batch.keys = append(batch.keys, key)
+	}
+
+	return batch
+}
+
+func (b *Coalescer[K, V]) flush(batch *coalesced[K, V]) {
+	b.mu.Lock()
+	if b.pending == batch {
+		b.pending = nil
+	}
+	b.mu.Unlock()
+	defer close(batch.done)
+
+	ctx, cancel := b.c.loaderContext(context.Background())
+	defer cancel()
+
+	start := b.c.clock.Monotonic()
+	batch.values, batch.err = b.loadBatch(ctx, batch.keys)
+	b.c.countLoaderTimeout(ctx, batch.err)
+	share := (b.c.clock.Monotonic() - start) / time.Duration(len(batch.keys))
+	for _, key := range batch.keys {
+		b.c.recordPenalty(key, 1, share)
+	}
+	if batch.err == nil && len(batch.values) > 0 {
+		entries := make([]Entry[K, V], 0, len(batch.values))
+		for key, value := range batch.values {
+			entries = append(entries, Entry[K, V]{Key: key, Value: value, TTL: b.ttl})
+		}
+		b.c.SetMany(entries)
+	}
+}
+
+// loadBatch calls the loader, turning a panic into ErrLoaderPanic: the
+// batch is loaded on a goroutine of its own, where a panic would end the
+// process.
+func (b *Coalescer[K, V]) loadBatch(ctx context.Context, keys []K) (values map[K]V, err error) {
+	defer func() {
+		if recover() != nil {
+			values, err = nil, ErrLoaderPanic
+		}
+	}()
+
+	return b.load(ctx, keys)
+}
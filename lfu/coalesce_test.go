@@ -0,0 +1,33 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestCoalescerLoaderPanic(t *testing.T) {
+	c := lfu.NewCache[string, int](8, time.Hour, 0)
+	defer c.Shutdown(context.Background())
+
+	b := c.Coalesce(time.Millisecond, time.Hour, func(ctx context.Context, keys []string) (map[string]int, error) {
+		panic("origin failed")
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.Get(context.Background(), "a")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != lfu.ErrLoaderPanic {
+			t.Fatalf("Get = %v, want ErrLoaderPanic", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Get did not return after the loader panicked")
+	}
+}
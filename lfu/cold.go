@@ -0,0 +1,18 @@
+package lfu
+
+import "time"
+
+// WithColdPurge makes every maintenance sweep remove entries whose frequency
+// is still below minFreq once they have been resident for at least
+// residency, regardless of how full the cache is. It has no effect unless
+// the cache was created with a positive cleanup interval.
+func WithColdPurge(minFreq uint64, residency time.Duration) Option {
+	return func(c *settings) {
+		c.coldFreq = minFreq
+		c.coldResidency = residency
+	}
+}
+
+func (c *Cache[K, V]) isCold(item *Item[K, V], now time.Time) bool {
+	return item.Frequency < c.coldFreq && now.Sub(item.created) >= c.coldResidency
+}
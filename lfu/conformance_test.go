@@ -0,0 +1,27 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cache "github.com/grrrance/lfu-in-memory"
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestInMemoryCacheConformance(t *testing.T) {
+	cachetest.RunLFUConformance(t, func(capacity int, defaultTTL time.Duration) cache.InMemoryLFU {
+		c := lfu.NewInMemoryCache(capacity, defaultTTL, 0)
+		t.Cleanup(func() { c.Shutdown(context.Background()) })
+		return c
+	})
+}
+
+func TestReadMostlyConformance(t *testing.T) {
+	cachetest.RunLFUConformance(t, func(capacity int, defaultTTL time.Duration) cache.InMemoryLFU {
+		c := lfu.NewInMemoryCache(capacity, defaultTTL, 0, lfu.WithReadMostly())
+		t.Cleanup(func() { c.Shutdown(context.Background()) })
+		return c
+	})
+}
@@ -0,0 +1,42 @@
+package lfu
+
+// WithCost charges each value fn(value) units of the cache size instead of
+// one, e.g. its length in bytes. Results below one count as one. Retained
+// history versions are charged the same way, and the oldest are dropped
+// when an entry would not fit on its own. A write whose value alone costs
+// more than the cache size is rejected with ErrItemTooLarge. Costs are
+// computed when a value is written, so in-place changes made by Update are
+// not reflected.
+func WithCost(fn func(value interface{}) int) Option {
+	return func(c *settings) {
+		c.costFunc = fn
+	}
+}
+
+func (c *Cache[K, V]) valueCost(value V) int {
+	if c.costFunc == nil {
+		return 1
+	}
+
+	if n := c.costFunc(any(value)); n > 1 {
+		return n
+	}
+
+	return 1
+}
+
+// weigh recomputes item's cost, dropping the oldest history versions until
+// the entry fits in the cache.
+func (c *Cache[K, V]) weigh(item *Item[K, V]) {
+	item.weight = c.valueCost(item.Value)
+	for _, old := range item.history {
+		item.weight += c.valueCost(old)
+	}
+
+	for item.weight > c.size && len(item.history) > 0 {
+		oldest := item.history[0]
+		item.weight -= c.valueCost(oldest)
+		item.history = item.history[1:]
+		c.discard(oldest, item.Value)
+	}
+}
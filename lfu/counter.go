@@ -0,0 +1,104 @@
+package lfu
+
+// Increment adds delta to the integer stored under key and returns the
+// result, keeping the entry's expiration. An absent or expired key starts
+// from zero and is stored with the default expiration. It returns
+// ErrNotNumeric when the stored value is not an integer, or when V cannot
+// hold one. Integers of any width wrap around on overflow.
+func (c *Cache[K, V]) Increment(key K, delta int64) (int64, error) {
+	defer c.slowKeyOp("set", key, c.slowStart())
+
+	if err := c.writable(); err != nil {
+		return 0, err
+	}
+	if c.size <= 0 {
+		return 0, c.misuse(ErrZeroCapacity)
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	item, found := c.items[key]
+	if found && !c.isExpired(item) {
+		value, n, ok := addInteger(any(item.Value), delta)
+		if !ok {
+			return 0, ErrNotNumeric
+		}
+
+		c.cost -= item.cost()
+		item.Value = value.(V)
+		item.owner = ""
+		c.audit("", auditSet, key, &item.Value)
+		c.seal(item)
+		c.weigh(item)
+		c.cost += item.cost()
+		c.upgradeItem(item, key)
+		c.publish(key, item)
+		c.counters.sets.Add(1)
+		return n, nil
+	}
+
+	var zero V
+	start := any(zero)
+	if start == nil {
+		start = int64(0)
+	}
+	value, n, ok := addInteger(start, delta)
+	if !ok {
+		return 0, ErrNotNumeric
+	}
+
+	switch c.put("", key, value.(V), 0) {
+	case SetTooLarge:
+		return 0, ErrItemTooLarge
+	case SetNotAdmitted:
+		return 0, ErrNotAdmitted
+	}
+
+	return n, nil
+}
+
+// Decrement is Increment with the delta negated.
+func (c *Cache[K, V]) Decrement(key K, delta int64) (int64, error) {
+	return c.Increment(key, -delta)
+}
+
+// addInteger adds delta to v, keeping v's type.
+func addInteger(v any, delta int64) (any, int64, bool) {
+	switch v := v.(type) {
+	case int:
+		n := v + int(delta)
+		return n, int64(n), true
+	case int8:
+		n := v + int8(delta)
+		return n, int64(n), true
+	case int16:
+		n := v + int16(delta)
+		return n, int64(n), true
+	case int32:
+		n := v + int32(delta)
+		return n, int64(n), true
+	case int64:
+		n := v + delta
+		return n, n, true
+	case uint:
+		n := v + uint(delta)
+		return n, int64(n), true
+	case uint8:
+		n := v + uint8(delta)
+		return n, int64(n), true
+	case uint16:
+		n := v + uint16(delta)
+		return n, int64(n), true
+	case uint32:
+		n := v + uint32(delta)
+		return n, int64(n), true
+	case uint64:
+		n := v + uint64(delta)
+		return n, int64(n), true
+	}
+
+	return nil, 0, false
+}
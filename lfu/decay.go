@@ -0,0 +1,76 @@
+package lfu
+
+import "time"
+
+// WithFrequencyDecay halves every entry's frequency, down to a minimum of
+// one, each interval and after every ops accesses and writes, so entries
+// whose traffic has died down become evictable again. Either trigger may
+// be zero to disable it. Halving keeps the relative eviction order of
+// entries except where it makes their frequencies equal.
+func WithFrequencyDecay(interval time.Duration, ops uint64) Option {
+	return func(c *settings) {
+		c.decayInterval = interval
+		c.decayOps = ops
+	}
+}
+
+func (c *Cache[K, V]) startDecay(ticker Ticker) {
+	defer c.workers.Done()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C():
+		}
+
+		c.lock(opSweep)
+		c.drainHits()
+		c.decay()
+		c.unlock()
+		ticker.Done()
+	}
+}
+
+// countOp counts one access or write towards WithFrequencyDecay's ops.
+func (c *Cache[K, V]) countOp() {
+	if c.decayOps == 0 {
+		return
+	}
+
+	if c.opsSinceDecay++; c.opsSinceDecay >= c.decayOps {
+		c.decay()
+	}
+}
+
+// decay halves all frequencies. Buckets are visited lowest first and
+// halving never reorders frequencies, so each item is placed at or after
+// the bucket the previous one went to.
+func (c *Cache[K, V]) decay() {
+	c.opsSinceDecay = 0
+
+	old := c.lowest
+	c.lowest = nil
+
+	var last *bucket[K, V]
+	for old != nil {
+		next := old.next
+		for item := old.head; item != nil; {
+			following := item.next
+			item.Frequency = max(1, item.Frequency/2)
+			c.place(item, last)
+			last = item.bucket
+			item = following
+		}
+		*old = bucket[K, V]{}
+		if len(c.spareGroups) < spareGroupsLimit {
+			c.spareGroups = append(c.spareGroups, old)
+		}
+		old = next
+	}
+
+	for key, freq := range c.freqHints {
+		c.freqHints[key] = max(1, freq/2)
+	}
+}
@@ -0,0 +1,78 @@
+package lfu
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNilCache     = errors.New("Cache is nil")
+	ErrCacheClosed  = errors.New("Cache is closed")
+	ErrZeroCapacity = errors.New("Cache capacity is zero")
+	ErrNegativeTTL  = errors.New("Negative TTL")
+	ErrNilCallback  = errors.New("Nil callback")
+	ErrCallbackType = errors.New("Callback does not match the cache types")
+	ErrNotMap       = errors.New("Value is not a map")
+	ErrKeyNotFound  = errors.New("Key not found")
+	ErrItemTooLarge = errors.New("Item is larger than the cache")
+	ErrLeaseTimeout = errors.New("Timed out waiting for lease")
+	ErrNotAdmitted  = errors.New("Item was not admitted")
+	ErrLoaderPanic  = errors.New("Loader panicked")
+	ErrNotNumeric   = errors.New("Value is not an integer")
+	ErrKeyExists    = errors.New("Key already exists")
+	ErrBadSchedule  = errors.New("Schedule does not advance")
+)
+
+// usable reports why c cannot serve requests. A nil cache behaves like an
+// empty cache that rejects writes, and so does a cache after Shutdown: reads
+// miss, writes are dropped and error-returning methods return ErrNilCache or
+// ErrCacheClosed.
+func (c *Cache[K, V]) usable() error {
+	if c == nil {
+		return ErrNilCache
+	}
+
+	if c.closed.Load() {
+		return ErrCacheClosed
+	}
+
+	return nil
+}
+
+// WithStrictMode turns API misuse into panics: writes to a nil, closed or
+// zero-capacity cache, negative TTLs, nil callbacks and flush schedules
+// that do not advance. Without it such calls keep their lenient behavior,
+// returning the typed error where the method has an error result and doing
+// nothing otherwise. Negative TTLs fall back to the default expiration.
+func WithStrictMode() Option {
+	return func(c *settings) {
+		c.strict = true
+	}
+}
+
+func (c *Cache[K, V]) misuse(err error) error {
+	if err != nil && c != nil && c.strict {
+		panic(fmt.Sprintf("lfu: %v", err))
+	}
+
+	return err
+}
+
+func (c *Cache[K, V]) writable() error {
+	return c.misuse(c.usable())
+}
+
+func (c *Cache[K, V]) checkTTL(duration time.Duration) {
+	if duration < 0 {
+		c.misuse(ErrNegativeTTL)
+	}
+}
+
+func (c *Cache[K, V]) checkCallbacks(missing bool) error {
+	if missing {
+		return c.misuse(ErrNilCallback)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package lfu
+
+import "container/list"
+
+// EvictionPolicy chooses capacity victims in place of the built-in LFU
+// ordering. The cache calls OnAdd when a key is stored, OnAccess when it
+// is read or overwritten and OnRemove when it leaves, for any reason,
+// including eviction. Victim returns the key to evict next other than
+// exclude, which is the key being written. Methods are called with the
+// cache lock held and must not use the cache.
+type EvictionPolicy[K comparable] interface {
+	OnAdd(key K)
+	OnAccess(key K)
+	OnRemove(key K)
+	Victim(exclude K) (K, bool)
+}
+
+// WithEvictionPolicy makes the cache evict by the policy newPolicy returns.
+// A factory is taken so every cache built with the option, such as each
+// shard of a Sharded cache, gets its own policy. Frequencies are still
+// counted and reported, but WithTieBreak and WithFrequencyLevels no longer
+// affect eviction, and PeekVictims reports nothing. Its key
+// type must match the cache's; a mismatched factory is ignored, or panics
+// in strict mode.
+func WithEvictionPolicy[K comparable](newPolicy func() EvictionPolicy[K]) Option {
+	return func(c *settings) {
+		c.policyFunc = newPolicy
+	}
+}
+
+type lfuPolicy[K comparable] struct {
+	EvictionPolicy[K]
+}
+
+// NewLFUPolicy selects the built-in LFU ordering, which is the default.
+func NewLFUPolicy[K comparable]() EvictionPolicy[K] {
+	return lfuPolicy[K]{}
+}
+
+// orderPolicy keeps keys in a list, next victim first.
+type orderPolicy[K comparable] struct {
+	order    list.List
+	elements map[K]*list.Element
+	recency  bool
+}
+
+// NewLRUPolicy evicts the least recently used key.
+func NewLRUPolicy[K comparable]() EvictionPolicy[K] {
+	return &orderPolicy[K]{elements: make(map[K]*list.Element), recency: true}
+}
+
+// NewFIFOPolicy evicts the key stored earliest, regardless of use.
+func NewFIFOPolicy[K comparable]() EvictionPolicy[K] {
+	return &orderPolicy[K]{elements: make(map[K]*list.Element)}
+}
+
+func (p *orderPolicy[K]) OnAdd(key K) {
+	if element, ok := p.elements[key]; ok {
+		p.order.MoveToBack(element)
+		return
+	}
+
+	p.elements[key] = p.order.PushBack(key)
+}
+
+func (p *orderPolicy[K]) OnAccess(key K) {
+	if element, ok := p.elements[key]; ok && p.recency {
+		p.order.MoveToBack(element)
+	}
+}
+
+func (p *orderPolicy[K]) OnRemove(key K) {
+	if element, ok := p.elements[key]; ok {
+		p.order.Remove(element)
+		delete(p.elements, key)
+	}
+}
+
+func (p *orderPolicy[K]) Victim(exclude K) (K, bool) {
+	for element := p.order.Front(); element != nil; element = element.Next() {
+		if key := element.Value.(K); key != exclude {
+			return key, true
+		}
+	}
+
+	var zero K
+	return zero, false
+}
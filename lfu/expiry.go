@@ -0,0 +1,75 @@
+package lfu
+
+import "container/heap"
+
+// expiryHeap orders the cached items by the deadline pastDeadline checks,
+// so that expired entries can be reclaimed before a live one is evicted
+// without scanning the cache.
+type expiryHeap[K comparable, V any] struct {
+	items     []*Item[K, V]
+	monotonic bool
+}
+
+func (h *expiryHeap[K, V]) Len() int {
+	return len(h.items)
+}
+
+func (h *expiryHeap[K, V]) Less(i, j int) bool {
+	if h.monotonic {
+		return h.items[i].deadline < h.items[j].deadline
+	}
+
+	return h.items[i].Expiration.Before(h.items[j].Expiration)
+}
+
+func (h *expiryHeap[K, V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].expiryIndex = i + 1
+	h.items[j].expiryIndex = j + 1
+}
+
+func (h *expiryHeap[K, V]) Push(x any) {
+	item := x.(*Item[K, V])
+	h.items = append(h.items, item)
+	item.expiryIndex = len(h.items)
+}
+
+func (h *expiryHeap[K, V]) Pop() any {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items[last] = nil
+	h.items = h.items[:last]
+	item.expiryIndex = 0
+
+	return item
+}
+
+// trackExpiry adds item to the expiry heap or moves it after its
+// expiration changed. expiryIndex is one past the item's position, so zero
+// means untracked.
+func (c *Cache[K, V]) trackExpiry(item *Item[K, V]) {
+	if item.expiryIndex == 0 {
+		heap.Push(&c.expiries, item)
+	} else {
+		heap.Fix(&c.expiries, item.expiryIndex-1)
+	}
+}
+
+func (c *Cache[K, V]) untrackExpiry(item *Item[K, V]) {
+	if item.expiryIndex != 0 {
+		heap.Remove(&c.expiries, item.expiryIndex-1)
+	}
+}
+
+func (c *Cache[K, V]) mayHaveExpired() bool {
+	return len(c.expiries.items) > 0 && c.isReapable(c.expiries.items[0])
+}
+
+// removeReapable removes every entry that is expired and past its read
+// grace, earliest first, in time proportional to their number.
+func (c *Cache[K, V]) removeReapable() {
+	for c.mayHaveExpired() {
+		item := c.expiries.items[0]
+		c.removeItem(item, item.key, ReasonExpired)
+	}
+}
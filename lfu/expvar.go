@@ -0,0 +1,35 @@
+package lfu
+
+import (
+	"expvar"
+	"sync"
+)
+
+var expvarCaches sync.Map
+
+// WithExpvar publishes the cache's StatsSnapshot through expvar under name
+// until the cache is shut down; a later cache published under the same
+// name takes its place. name must not be used by other expvar variables.
+func WithExpvar(name string) Option {
+	return func(c *settings) {
+		c.expvarName = name
+	}
+}
+
+func publishExpvar(name string, c statsSource) {
+	if _, loaded := expvarCaches.Swap(name, c); loaded || expvar.Get(name) != nil {
+		return
+	}
+
+	expvar.Publish(name, expvar.Func(func() any {
+		c, ok := expvarCaches.Load(name)
+		if !ok {
+			return nil
+		}
+		return c.(statsSource).Stats().Snapshot()
+	}))
+}
+
+func unpublishExpvar(name string, c statsSource) {
+	expvarCaches.CompareAndDelete(name, c)
+}
@@ -0,0 +1,76 @@
+package lfu
+
+import "time"
+
+// SetField sets field in the map[string]interface{} stored under key,
+// creating the map if key is absent or expired, and resets the key's
+// expiration to ttl. The map is copied rather than modified in place, so
+// maps already returned by Get are never mutated. It returns ErrNotMap if
+// key holds any other type, or if V cannot hold a map[string]interface{},
+// and ErrNotAdmitted when WithTinyLFU keeps a new key out.
+func (c *Cache[K, V]) SetField(key K, field string, value interface{}, ttl time.Duration) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+	if c.size <= 0 {
+		return c.misuse(ErrZeroCapacity)
+	}
+	c.checkTTL(ttl)
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	item, found := c.items[key]
+	if !found {
+		c.promoteOverflow(key)
+		item, found = c.items[key]
+	}
+
+	var fields map[string]interface{}
+	if found && !c.isExpired(item) {
+		current, ok := any(item.Value).(map[string]interface{})
+		if !ok {
+			return ErrNotMap
+		}
+		fields = make(map[string]interface{}, len(current)+1)
+		for k, v := range current {
+			fields[k] = v
+		}
+	} else {
+		fields = make(map[string]interface{}, 1)
+	}
+	fields[field] = value
+
+	stored, ok := any(fields).(V)
+	if !ok {
+		return ErrNotMap
+	}
+	switch c.put("", key, stored, ttl) {
+	case SetTooLarge:
+		return ErrItemTooLarge
+	case SetNotAdmitted:
+		return ErrNotAdmitted
+	}
+
+	return nil
+}
+
+// GetField returns field from the map[string]interface{} stored under key.
+// It counts as a hit on key whenever the key itself is found.
+func (c *Cache[K, V]) GetField(key K, field string) (interface{}, bool) {
+	value, found := c.Get(key)
+	if !found {
+		return nil, false
+	}
+
+	fields, ok := any(value).(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	v, found := fields[field]
+
+	return v, found
+}
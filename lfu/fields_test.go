@@ -0,0 +1,26 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestSetFieldNotAdmitted(t *testing.T) {
+	c := lfu.NewInMemoryCache(1, time.Hour, 0, lfu.WithTinyLFU())
+	defer c.Shutdown(context.Background())
+
+	c.SetField("hot", "f", 1, 0)
+	for i := 0; i < 10; i++ {
+		c.Get("hot")
+	}
+
+	if err := c.SetField("cold", "f", 1, 0); err != lfu.ErrNotAdmitted {
+		t.Fatalf("SetField = %v, want ErrNotAdmitted", err)
+	}
+	if _, ok := c.GetField("cold", "f"); ok {
+		t.Fatal("rejected field was stored")
+	}
+}
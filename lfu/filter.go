@@ -0,0 +1,114 @@
+package lfu
+
+import (
+	"hash/maphash"
+	"sync/atomic"
+)
+
+const (
+	filterHashes         = 4
+	filterCountersPerKey = 8
+)
+
+// negativeFilter is a counting Bloom filter over the keys held by the cache.
+// Its counters are updated with the cache lock held and read without it, so
+// a zero counter proves a key absent without touching the lock. A cuckoo
+// filter was considered, but relocating fingerprints while lock-free
+// readers probe it can hide a present key, which a counting filter cannot.
+type negativeFilter struct {
+	seed     maphash.Seed
+	counters []atomic.Uint32
+	mask     uint64
+}
+
+// WithNegativeFilter puts a probabilistic filter in front of the cache so
+// that Gets for keys that are certainly absent return without taking the
+// lock. It costs about 32 bytes of memory per entry of capacity and only
+// takes effect for caches with string keys.
+func WithNegativeFilter() Option {
+	return func(c *settings) {
+		c.filter = &negativeFilter{seed: maphash.MakeSeed()}
+	}
+}
+
+func (c *Cache[K, V]) filterAdd(key K) {
+	if c.filter != nil {
+		if s, ok := any(key).(string); ok {
+			c.filter.add(s)
+		}
+	}
+}
+
+func (c *Cache[K, V]) filterRemove(key K) {
+	if c.filter != nil {
+		if s, ok := any(key).(string); ok {
+			c.filter.remove(s)
+		}
+	}
+}
+
+func (c *Cache[K, V]) filterMayContain(key K) bool {
+	if c.filter == nil {
+		return true
+	}
+
+	s, ok := any(key).(string)
+
+	return !ok || c.filter.mayContain(s)
+}
+
+func (f *negativeFilter) allocate(capacity int) {
+	size := uint64(1)
+	for size < uint64(capacity)*filterCountersPerKey {
+		size <<= 1
+	}
+
+	f.counters = make([]atomic.Uint32, size)
+	f.mask = size - 1
+}
+
+func (f *negativeFilter) positions(key string) [filterHashes]uint64 {
+	h := maphash.String(f.seed, key)
+	h1, h2 := h&0xffffffff, h>>32|1
+
+	var positions [filterHashes]uint64
+	for i := range positions {
+		positions[i] = (h1 + uint64(i)*h2) & f.mask
+	}
+
+	return positions
+}
+
+func (f *negativeFilter) add(key string) {
+	if f == nil {
+		return
+	}
+
+	for _, p := range f.positions(key) {
+		f.counters[p].Add(1)
+	}
+}
+
+func (f *negativeFilter) remove(key string) {
+	if f == nil {
+		return
+	}
+
+	for _, p := range f.positions(key) {
+		f.counters[p].Add(^uint32(0))
+	}
+}
+
+func (f *negativeFilter) mayContain(key string) bool {
+	if f == nil {
+		return true
+	}
+
+	for _, p := range f.positions(key) {
+		if f.counters[p].Load() == 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,186 @@
+package lfu
+
+import (
+	"strings"
+	"time"
+)
+
+// FlushSchedule picks the flush times for ScheduleFlush.
+type FlushSchedule interface {
+	// Next returns the first flush time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+type everySchedule time.Duration
+
+func (d everySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+// Every flushes every d, counted from when the schedule starts.
+func Every(d time.Duration) FlushSchedule {
+	return everySchedule(d)
+}
+
+type dailySchedule struct {
+	hour, minute int
+	loc          *time.Location
+}
+
+func (s dailySchedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc)
+	next := time.Date(t.Year(), t.Month(), t.Day(), s.hour, s.minute, 0, 0, s.loc)
+	if !next.After(t) {
+		next = time.Date(t.Year(), t.Month(), t.Day()+1, s.hour, s.minute, 0, 0, s.loc)
+	}
+
+	return next
+}
+
+// Daily flushes once a day at hour:minute wall-clock time in loc, or in
+// UTC when loc is nil.
+func Daily(hour, minute int, loc *time.Location) FlushSchedule {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return dailySchedule{hour: hour, minute: minute, loc: loc}
+}
+
+// Flush deletes every entry, including overflowed ones, and returns how
+// many were removed.
+func (c *Cache[K, V]) Flush() int {
+	return c.flush(nil)
+}
+
+// FlushNamespace deletes every entry whose key lies in one of namespaces,
+// as built with Key, and returns how many were removed.
+func (c *Cache[K, V]) FlushNamespace(namespaces ...string) int {
+	if len(namespaces) == 0 {
+		return 0
+	}
+
+	return c.flush(namespaces)
+}
+
+func (c *Cache[K, V]) flush(namespaces []string) int {
+	if c.writable() != nil {
+		return 0
+	}
+
+	prefixes := make([]string, len(namespaces))
+	for i, ns := range namespaces {
+		prefixes[i] = namespacePrefix(ns)
+	}
+	matches := func(key K) bool {
+		if len(prefixes) == 0 {
+			return true
+		}
+		s, ok := any(key).(string)
+		for _, prefix := range prefixes {
+			if ok && strings.HasPrefix(s, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	c.lock(opDelete)
+	defer c.unlock()
+
+	c.drainHits()
+
+	var removed int
+	for key, item := range c.items {
+		if matches(key) {
+			c.removeItem(item, key, ReasonDelete)
+			c.audit("", auditDelete, key, nil)
+			removed++
+		}
+	}
+	if c.overflow != nil {
+		for key := range c.overflow.entries {
+			if matches(key) {
+				c.evictOverflow(key, ReasonDelete)
+				c.audit("", auditDelete, key, nil)
+				removed++
+			}
+		}
+	}
+
+	return removed
+}
+
+// ScheduleFlush flushes namespaces, or the whole cache when none are
+// given, at each time picked by schedule until the returned stop function
+// is called or the cache is shut down. A schedule whose next time is not
+// after the current one, such as Every(0), is rejected with
+// ErrBadSchedule, and ends the schedule if it happens later.
+func (c *Cache[K, V]) ScheduleFlush(schedule FlushSchedule, namespaces ...string) (stop func()) {
+	if c.writable() != nil || c.checkCallbacks(schedule == nil) != nil {
+		return func() {}
+	}
+
+	now := c.clock.Now()
+	next := schedule.Next(now)
+	if !next.After(now) {
+		c.misuse(ErrBadSchedule)
+		return func() {}
+	}
+
+	stopped := make(chan struct{})
+	var once bool
+
+	c.lock(opSet)
+	if c.closed.Load() {
+		c.unlock()
+		return func() {}
+	}
+	c.workers.Add(1)
+	c.unlock()
+
+	// Each wait is a one-shot ticker rather than a timer so that a test
+	// clock waits for the flush; the next ticker exists before the current
+	// one is released.
+	ticker := c.newTicker(next.Sub(now))
+	go func() {
+		defer c.workers.Done()
+
+		for {
+			select {
+			case <-c.done:
+				ticker.Stop()
+				return
+			case <-stopped:
+				ticker.Stop()
+				return
+			case <-ticker.C():
+			}
+
+			c.flush(namespaces)
+
+			now := c.clock.Now()
+			if next = schedule.Next(next); !next.After(now) {
+				if next = schedule.Next(now); !next.After(now) {
+					ticker.Done()
+					ticker.Stop()
+					return
+				}
+			}
+			fired := ticker
+			ticker = c.newTicker(next.Sub(now))
+			fired.Done()
+			fired.Stop()
+		}
+	}()
+
+	return func() {
+		c.lock(opSet)
+		defer c.unlock()
+
+		if !once {
+			once = true
+			close(stopped)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestScheduleFlushRejectsNonAdvancingSchedule(t *testing.T) {
+	c, clock := newClockCache(t, 4, 0)
+
+	stop := c.ScheduleFlush(lfu.Every(0))
+	defer stop()
+
+	c.Set("a", 1, 0)
+	clock.Advance(time.Second)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Every(0) flushed the cache")
+	}
+
+	strict := lfu.NewInMemoryCache(4, time.Hour, 0, lfu.WithStrictMode())
+	defer strict.Shutdown(context.Background())
+	defer func() {
+		if recover() == nil {
+			t.Fatal("strict mode accepted Every(-1)")
+		}
+	}()
+	strict.ScheduleFlush(lfu.Every(-1))
+}
+
+func TestScheduleFlushDaily(t *testing.T) {
+	c, _ := newClockCache(t, 4, 0)
+
+	stop := c.ScheduleFlush(lfu.Daily(0, 0, nil), "price")
+	defer stop()
+
+	c.Set(lfu.Key("price", "a"), 1, 48*time.Hour)
+	c.Set(lfu.Key("other", "a"), 1, 48*time.Hour)
+	cachetest.AdvanceTime(c, 24*time.Hour)
+
+	if _, ok := c.Get(lfu.Key("price", "a")); ok {
+		t.Fatal("namespace was not flushed at midnight")
+	}
+	if _, ok := c.Get(lfu.Key("other", "a")); !ok {
+		t.Fatal("flush removed a key outside the namespace")
+	}
+}
+
+func TestScheduleFlushEvery(t *testing.T) {
+	c, _ := newClockCache(t, 4, 0)
+
+	stop := c.ScheduleFlush(lfu.Every(time.Minute))
+	defer stop()
+
+	for i := 0; i < 3; i++ {
+		c.Set("a", i, time.Hour)
+		cachetest.AdvanceTime(c, time.Minute)
+		if _, ok := c.Get("a"); ok {
+			t.Fatalf("flush %d had not run when AdvanceTime returned", i+1)
+		}
+	}
+}
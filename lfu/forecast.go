@@ -0,0 +1,65 @@
+package lfu
+
+import (
+	"math"
+	"time"
+)
+
+// Forecast projects the cache's occupancy Horizon ahead. InsertRate and
+// RemoveRate are entries per second averaged since the cache was created,
+// RemoveRate covering deletions and expirations but not evictions. Len and
+// Cost are the projections, capped at Capacity; TimeToFull is how long
+// until the cost reaches Capacity at the current rates, or zero if the
+// cache is not growing.
+type Forecast struct {
+	Horizon    time.Duration
+	Len        int
+	Cost       int
+	Capacity   int
+	InsertRate float64
+	RemoveRate float64
+	TimeToFull time.Duration
+}
+
+// ForecastOccupancy extrapolates the average insert and removal rates over
+// horizon. It assumes new entries cost as much as the current ones do on
+// average.
+func (c *Cache[K, V]) ForecastOccupancy(horizon time.Duration) Forecast {
+	forecast := Forecast{Horizon: horizon}
+	if c.usable() != nil {
+		return forecast
+	}
+
+	c.lock(opStats)
+	length, cost, inserted := len(c.items), c.cost, c.insertions
+	forecast.Capacity = c.size
+	elapsed := (c.clock.Monotonic() - c.started).Seconds()
+	c.unlock()
+
+	forecast.Len, forecast.Cost = length, cost
+	if elapsed <= 0 {
+		return forecast
+	}
+
+	removed := c.counters.deletes.Load() + c.counters.expirations.Load()
+	forecast.InsertRate = float64(inserted) / elapsed
+	forecast.RemoveRate = float64(removed) / elapsed
+
+	costPerEntry := 1.0
+	if length > 0 {
+		costPerEntry = float64(cost) / float64(length)
+	}
+
+	growth := forecast.InsertRate - forecast.RemoveRate
+	projected := float64(length) + growth*horizon.Seconds()
+	projected = math.Max(0, math.Min(projected, float64(forecast.Capacity)/costPerEntry))
+	forecast.Len = int(projected)
+	forecast.Cost = int(math.Min(projected*costPerEntry, float64(forecast.Capacity)))
+
+	if growth > 0 && cost < forecast.Capacity {
+		seconds := float64(forecast.Capacity-cost) / (growth * costPerEntry)
+		forecast.TimeToFull = time.Duration(seconds * float64(time.Second))
+	}
+
+	return forecast
+}
@@ -0,0 +1,164 @@
+package lfu
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+const frequencyTableVersion = 1
+
+var (
+	frequencyTableMagic = [4]byte{'L', 'F', 'U', 'F'}
+
+	errMalformedFrequencies = errors.New("Malformed frequency table")
+)
+
+// FrequencyTable maps keys to access frequencies, for sharing the
+// popularity ranking between replicas. Its binary form is the magic "LFUF",
+// a version byte and a uvarint entry count, followed by each key as a
+// uvarint length and its bytes and each frequency as a uvarint.
+type FrequencyTable map[string]uint64
+
+func (t FrequencyTable) MarshalBinary() ([]byte, error) {
+	keys := make([]string, 0, len(t))
+	size := len(frequencyTableMagic) + 1 + binary.MaxVarintLen64
+	for key := range t {
+		keys = append(keys, key)
+		size += len(key) + 2*binary.MaxVarintLen64
+	}
+	sort.Strings(keys)
+
+	buf := make([]byte, 0, size)
+	buf = append(buf, frequencyTableMagic[:]...)
+	buf = append(buf, frequencyTableVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(keys)))
+	for _, key := range keys {
+		buf = binary.AppendUvarint(buf, uint64(len(key)))
+		buf = append(buf, key...)
+		buf = binary.AppendUvarint(buf, t[key])
+	}
+
+	return buf, nil
+}
+
+func (t *FrequencyTable) UnmarshalBinary(data []byte) error {
+	if len(data) < len(frequencyTableMagic)+1 || [4]byte(data[:4]) != frequencyTableMagic || data[4] != frequencyTableVersion {
+		return errMalformedFrequencies
+	}
+	data = data[5:]
+
+	n, read := binary.Uvarint(data)
+	if read <= 0 || n > uint64(len(data)) {
+		return errMalformedFrequencies
+	}
+	data = data[read:]
+
+	table := make(FrequencyTable, n)
+	for i := uint64(0); i < n; i++ {
+		length, read := binary.Uvarint(data)
+		if read <= 0 || length > uint64(len(data)-read) {
+			return errMalformedFrequencies
+		}
+		key := string(data[read : read+int(length)])
+		data = data[read+int(length):]
+
+		freq, read := binary.Uvarint(data)
+		if read <= 0 {
+			return errMalformedFrequencies
+		}
+		data = data[read:]
+
+		table[key] = freq
+	}
+	if len(data) != 0 {
+		return errMalformedFrequencies
+	}
+
+	*t = table
+
+	return nil
+}
+
+// Frequencies returns the frequencies of up to limit live entries, the most
+// frequent first; a non-positive limit returns all of them. Keys that are
+// not strings are formatted with fmt.Sprint.
+func (c *Cache[K, V]) Frequencies(limit int) FrequencyTable {
+	if c.usable() != nil {
+		return FrequencyTable{}
+	}
+
+	c.lock(opStats)
+	defer c.unlock()
+
+	c.drainHits()
+
+	items := make([]*Item[K, V], 0, len(c.items))
+	for _, item := range c.items {
+		if !c.isExpired(item) {
+			items = append(items, item)
+		}
+	}
+	if limit > 0 && limit < len(items) {
+		sort.Slice(items, func(i, j int) bool { return items[i].Frequency > items[j].Frequency })
+		items = items[:limit]
+	}
+
+	table := make(FrequencyTable, len(items))
+	for _, item := range items {
+		table[keyText(item.key)] = item.Frequency
+	}
+
+	return table
+}
+
+// ApplyFrequencies raises the frequency of every present key to the one in
+// table, never lowering it. Frequencies of absent keys are remembered, up to
+// the cache size with the most frequent kept, and used as the starting
+// frequency when the key is first stored. It returns the number of present
+// keys raised. Only caches with string keys are affected.
+func (c *Cache[K, V]) ApplyFrequencies(table FrequencyTable) int {
+	if c.writable() != nil {
+		return 0
+	}
+
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return table[keys[i]] > table[keys[j]] })
+
+	c.lock(opUpdate)
+	defer c.unlock()
+
+	c.drainHits()
+
+	var raised int
+	for _, s := range keys {
+		key, ok := any(s).(K)
+		if !ok {
+			return 0
+		}
+		freq := table[s]
+
+		item, found := c.items[key]
+		if !found {
+			if c.freqHints == nil {
+				c.freqHints = make(map[K]uint64)
+			}
+			if _, hinted := c.freqHints[key]; hinted || len(c.freqHints) < c.size {
+				c.freqHints[key] = freq
+			}
+			continue
+		}
+		if freq <= item.Frequency {
+			continue
+		}
+
+		item.Frequency = freq
+		c.move(item)
+		raised++
+	}
+
+	return raised
+}
@@ -0,0 +1,22 @@
+package lfu
+
+// GetOptions adjusts a single lookup. Bypass reports a miss without reading
+// or touching the entry. ForceRefresh also reports a miss but first deletes
+// the entry, so the caller's subsequent Set starts it afresh.
+type GetOptions struct {
+	Bypass       bool
+	ForceRefresh bool
+}
+
+func (c *Cache[K, V]) GetOpt(key K, opts GetOptions) (V, bool) {
+	var zero V
+	switch {
+	case opts.ForceRefresh:
+		_ = c.Delete(key)
+		return zero, false
+	case opts.Bypass:
+		return zero, false
+	}
+
+	return c.Get(key)
+}
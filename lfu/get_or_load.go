@@ -0,0 +1,83 @@
+package lfu
+
+import (
+	"context"
+	"time"
+)
+
+// Loader loads the value of key from the origin along with the TTL to
+// cache it for; a non-positive TTL selects the default expiration.
+type Loader[K comparable, V any] func(ctx context.Context, key K) (V, time.Duration, error)
+
+type loadCall[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+// GetOrLoad returns the cached value of key or, on a miss, loads and
+// caches it with loader. Concurrent misses on the same key share a single
+// loader call, which runs with the context of the caller that started it;
+// the others wait for its result or for their own ctx to end. A failed
+// load is returned to every waiter and nothing is cached; if the loader
+// panics, the waiters get ErrLoaderPanic. A value stored between the miss
+// and the start of the load is returned instead of loading.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V]) (V, error) {
+	defer c.slowKeyOp("load", key, c.slowStart())
+
+	var zero V
+	if err := c.usable(); err != nil {
+		return zero, err
+	}
+	if err := c.checkCallbacks(loader == nil); err != nil {
+		return zero, err
+	}
+
+	if value, found := c.Get(key); found {
+		return value, nil
+	}
+
+	c.lock(opGet)
+	c.drainHits()
+	if value, _, found := c.lookup(key); found {
+		c.unlock()
+		return value, nil
+	}
+	if call, ok := c.loads[key]; ok {
+		c.unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+	if c.loads == nil {
+		c.loads = make(map[K]*loadCall[V])
+	}
+	call := &loadCall[V]{done: make(chan struct{})}
+	c.loads[key] = call
+	c.unlock()
+
+	defer func() {
+		c.lock(opGet)
+		delete(c.loads, key)
+		c.unlock()
+		close(call.done)
+	}()
+
+	var ttl time.Duration
+	call.err = ErrLoaderPanic
+	ctx, cancel := c.loaderContext(ctx)
+	defer cancel()
+
+	start := c.clock.Monotonic()
+	call.value, ttl, call.err = loader(ctx, key)
+	c.countLoaderTimeout(ctx, call.err)
+	c.recordPenalty(key, 1, c.clock.Monotonic()-start)
+	if call.err == nil {
+		c.Set(key, call.value, ttl)
+	}
+
+	return call.value, call.err
+}
@@ -0,0 +1,62 @@
+package lfu_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+// opHandler calls onOp with the op of every slow-operation record.
+type opHandler struct {
+	onOp func(op string)
+}
+
+func (h opHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h opHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h opHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h opHandler) Handle(_ context.Context, r slog.Record) error {
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == "op" {
+			h.onOp(a.Value.String())
+		}
+		return true
+	})
+	return nil
+}
+
+func TestGetOrLoadRechecksAfterMiss(t *testing.T) {
+	var c *lfu.Cache[string, int]
+	var ops []string
+	setOnMiss := true
+	logger := slog.New(opHandler{onOp: func(op string) {
+		ops = append(ops, op)
+		// The slow Get log runs after the lock-free miss and before
+		// GetOrLoad takes the lock, so this Set lands in that window.
+		if op == "get" && setOnMiss {
+			setOnMiss = false
+			c.Set("k", 1, time.Hour)
+		}
+	}})
+	c, _ = newClockCache(t, 4, 0, lfu.WithSlowOpThreshold(time.Nanosecond, logger))
+
+	loader := func(context.Context, string) (int, time.Duration, error) {
+		t.Error("loader ran although the key was set after the miss")
+		return 2, time.Hour, nil
+	}
+	v, err := c.GetOrLoad(context.Background(), "k", loader)
+	if err != nil || v != 1 {
+		t.Fatalf("GetOrLoad = %v, %v, want 1, nil", v, err)
+	}
+
+	var logged bool
+	for _, op := range ops {
+		logged = logged || op == "load"
+	}
+	if !logged {
+		t.Fatalf("slow ops %v do not include load", ops)
+	}
+}
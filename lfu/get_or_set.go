@@ -0,0 +1,46 @@
+package lfu
+
+import "time"
+
+// GetOrSet returns the live value under key, raising its frequency, or
+// stores value for duration and returns it. loaded reports whether the
+// value was already cached. The lookup and the store happen under one
+// lock, so concurrent callers agree on a single value.
+func (c *Cache[K, V]) GetOrSet(key K, value V, duration time.Duration) (actual V, loaded bool, err error) {
+	defer c.slowKeyOp("set", key, c.slowStart())
+
+	if err := c.writable(); err != nil {
+		return actual, false, err
+	}
+	if c.size <= 0 {
+		return actual, false, c.misuse(ErrZeroCapacity)
+	}
+	c.checkTTL(duration)
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	if item, found := c.items[key]; found && !c.isExpired(item) {
+		c.recordAccess(key)
+		c.upgradeItem(item, key)
+		c.countLookup(true)
+		return item.Value, true, nil
+	} else if !found {
+		if cached, ok := c.promoteOverflow(key); ok {
+			c.countLookup(true)
+			return cached, true, nil
+		}
+	}
+	c.countLookup(false)
+
+	switch c.put("", key, value, duration) {
+	case SetTooLarge:
+		return actual, false, ErrItemTooLarge
+	case SetNotAdmitted:
+		return actual, false, ErrNotAdmitted
+	}
+
+	return c.items[key].Value, false, nil
+}
@@ -0,0 +1,54 @@
+package lfu
+
+import "time"
+
+// WithExpiredReadGrace keeps entries readable for up to d after they
+// expire. Such reads return the stale value as found, report it through
+// GetStale and are counted in Stats.GraceReads, but do not raise the
+// entry's frequency. Sweeps only remove entries once their grace has run
+// out.
+func WithExpiredReadGrace(d time.Duration) Option {
+	return func(c *settings) {
+		if d > 0 {
+			c.grace = d
+		}
+	}
+}
+
+// GetStale is Get that also reports whether the value was served from an
+// expired entry during its read grace.
+func (c *Cache[K, V]) GetStale(key K) (value V, stale, found bool) {
+	defer c.slowKeyOp("get", key, c.slowStart())
+
+	if c.usable() != nil {
+		return value, false, false
+	}
+	c.recordAccess(key)
+	if !c.filterMayContain(key) {
+		c.countLookup(false)
+		return value, false, false
+	}
+
+	if c.readMostly {
+		value, stale, found = c.getReadMostly(key)
+	} else {
+		value, stale, found = c.getLocked(key)
+	}
+	c.countLookup(found)
+
+	return value, stale, found
+}
+
+func (c *Cache[K, V]) inGrace(expiration time.Time, deadline time.Duration) bool {
+	return c.grace > 0 && !c.pastDeadline(expiration.Add(c.grace), deadline+c.grace)
+}
+
+// isReapable reports whether item is expired and past its read grace.
+func (c *Cache[K, V]) isReapable(item *Item[K, V]) bool {
+	return c.isExpired(item) && !c.inGrace(item.Expiration, item.deadline)
+}
+
+func (c *Cache[K, V]) graceRead(value V) (V, bool, bool) {
+	c.counters.graceReads.Add(1)
+	return value, true, true
+}
@@ -0,0 +1,57 @@
+package lfu
+
+// WithHistory keeps up to n previous values of every key. Each retained
+// version adds its cost to the entry's, one unit unless WithCost computes
+// otherwise.
+func WithHistory(n int) Option {
+	return func(c *settings) {
+		if n > 0 {
+			c.historyLen = n
+		}
+	}
+}
+
+func (c *Cache[K, V]) pushHistory(history []V, value, current V) []V {
+	limit := c.historyLen
+	if limit > c.size-1 {
+		limit = c.size - 1
+	}
+	if limit <= 0 {
+		for _, old := range history {
+			c.discard(old, current)
+		}
+		c.discard(value, current)
+		return nil
+	}
+
+	if len(history) >= limit {
+		for _, old := range history[:len(history)-limit+1] {
+			c.discard(old, current)
+		}
+		history = history[len(history)-limit+1:]
+	}
+
+	next := make([]V, len(history), len(history)+1)
+	copy(next, history)
+
+	return append(next, value)
+}
+
+// GetPrevious returns the value key held n versions ago, n = 1 being the
+// value replaced by the latest Set. It does not affect the key's frequency.
+func (c *Cache[K, V]) GetPrevious(key K, n int) (V, bool) {
+	var zero V
+	if c.usable() != nil {
+		return zero, false
+	}
+
+	c.lock(opGet)
+	defer c.unlock()
+
+	item, found := c.items[key]
+	if !found || n <= 0 || n > len(item.history) {
+		return zero, false
+	}
+
+	return item.history[len(item.history)-n], true
+}
@@ -1,199 +1,549 @@
 package lfu
 
 import (
-	"errors"
-	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-type InMemoryCache struct {
-	sync.Mutex
-	items             map[string]Item
-	freqGroup         map[uint64]map[string]struct{}
-	minFreq           uint64
-	defaultExpiration time.Duration
-	cleanupInterval   time.Duration
-	size              int
+const spareGroupsLimit = 64
+
+// Cache is an LFU cache with keys of type K and values of type V. Features
+// built around string keys, such as the path index, the negative filter and
+// namespace refresh, do nothing for other key types.
+type Cache[K comparable, V any] struct {
+	sync.RWMutex
+	settings
+	items          map[K]*Item[K, V]
+	lowest         *bucket[K, V]
+	spareGroups    []*bucket[K, V]
+	insertions     uint64
+	prioritized    int
+	opsSinceDecay  uint64
+	aboveWatermark bool
+	started        time.Duration
+	cost           int
+	expiries       expiryHeap[K, V]
+	overflow       *overflowCache[K, V]
+	links          []link[K]
+	permanent      map[K]V
+	refreshStatus  map[string]RefreshStatus
+	pending        []func()
+	onEvict        func(K, V, EvictionReason)
+	onExpire       func(K, V)
+	policy         EvictionPolicy[K]
+	sketch         *sketch
+	subscriptions  []subscription
+	leases         map[K]*lease
+	loads          map[K]*loadCall[V]
+	leaseToken     uint64
+	freqHints      map[K]uint64
+	subscriptionID uint64
+	counters       statsCounters
+	penalties      penaltyTracker
+	index          sync.Map
+	hits           chan K
+	sharedHits     hitBuffer[K]
+	lockStats      [lockOpCount]lockCounters
+	done           chan struct{}
+	workers        sync.WaitGroup
+	closed         atomic.Bool
 }
 
-type Item struct {
-	Value      interface{}
-	Expiration time.Time
-	Frequency  uint64
+// InMemoryCache is the string-keyed cache holding values of any type.
+type InMemoryCache = Cache[string, interface{}]
+
+type Item[K comparable, V any] struct {
+	Value       V
+	Expiration  time.Time
+	Frequency   uint64
+	history     []V
+	key         K
+	prev        *Item[K, V]
+	next        *Item[K, V]
+	bucket      *bucket[K, V]
+	inserted    uint64
+	checksum    uint64
+	priority    int
+	owner       string
+	created     time.Time
+	deadline    time.Duration
+	weight      int
+	expiryIndex int
 }
 
-func (i Item) isExpired() bool {
-	return time.Now().After(i.Expiration)
+func (i *Item[K, V]) cost() int {
+	return i.weight
 }
 
-func NewInMemoryCache(size int, defaultExpiration, cleanupInterval time.Duration) *InMemoryCache {
-	items := make(map[string]Item, size)
-	freqGroup := make(map[uint64]map[string]struct{}, size)
+func NewInMemoryCache(size int, defaultExpiration, cleanupInterval time.Duration, opts ...Option) *InMemoryCache {
+	return NewCache[string, interface{}](size, defaultExpiration, cleanupInterval, opts...)
+}
 
-	cache := InMemoryCache{
-		items:             items,
-		freqGroup:         freqGroup,
-		minFreq:           1,
+func NewCache[K comparable, V any](size int, defaultExpiration, cleanupInterval time.Duration, opts ...Option) *Cache[K, V] {
+	config := settings{
+		size:              size,
 		defaultExpiration: defaultExpiration,
 		cleanupInterval:   cleanupInterval,
-		size:              size,
+		clock:             systemClock{origin: time.Now()},
+		leaseTimeout:      defaultLeaseTimeout,
+		penaltyWindow:     defaultPenaltyWindow,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	cache := Cache[K, V]{
+		settings: config,
+		items:    make(map[K]*Item[K, V], config.size),
+		done:     make(chan struct{}),
+	}
+	cache.started = cache.clock.Monotonic()
+	cache.expiries.monotonic = config.monotonic
+
+	if config.onEvictFunc != nil {
+		fn, ok := config.onEvictFunc.(func(K, V, EvictionReason))
+		if !ok {
+			cache.misuse(ErrCallbackType)
+		}
+		cache.onEvict = fn
+	}
+
+	if config.onExpireFunc != nil {
+		fn, ok := config.onExpireFunc.(func(K, V))
+		if !ok {
+			cache.misuse(ErrCallbackType)
+		}
+		cache.onExpire = fn
+	}
+
+	if config.policyFunc != nil {
+		newPolicy, ok := config.policyFunc.(func() EvictionPolicy[K])
+		if !ok {
+			cache.misuse(ErrCallbackType)
+		} else if policy := newPolicy(); policy != nil {
+			if _, builtin := policy.(lfuPolicy[K]); !builtin {
+				cache.policy = policy
+			}
+		}
 	}
 
-	if cleanupInterval > 0 {
-		go cache.startGC()
+	if cache.wTinyLFU {
+		cache.policy = newWTinyLFU[K](cache.size)
+	}
+
+	if cache.tinyLFU {
+		cache.sketch = newSketch(cache.size)
+	}
+
+	if cache.overflowSize > 0 {
+		cache.overflow = newOverflowCache[K, V](cache.overflowSize)
+	}
+
+	if cache.readMostly {
+		cache.hits = make(chan K, readMostlyBuffer)
+	} else {
+		cache.sharedHits.slots = make([]K, min(cache.size, sharedHitsBuffer))
+	}
+
+	if cache.filter != nil {
+		cache.filter.allocate(cache.size + cache.overflowSize)
+	}
+
+	if cache.cleanupInterval > 0 {
+		cache.workers.Add(1)
+		go cache.startGC(cache.newTicker(cache.cleanupInterval))
+	}
+
+	if cache.decayInterval > 0 {
+		cache.workers.Add(1)
+		go cache.startDecay(cache.newTicker(cache.decayInterval))
+	}
+
+	if cache.webhook != nil {
+		cache.workers.Add(1)
+		go cache.dispatchWebhook(cache.newTicker(cache.webhook.FlushInterval))
+	}
+
+	if cache.name != "" {
+		register(&cache)
+	}
+
+	if cache.expvarName != "" {
+		publishExpvar(cache.expvarName, &cache)
 	}
 
 	return &cache
 }
 
-func (c *InMemoryCache) getExp(duration time.Duration) time.Time {
-	if duration <= 0 {
-		duration = c.defaultExpiration
+// Set stores value under key. It returns the reason when the value was
+// not stored: ErrNilCache, ErrCacheClosed, ErrZeroCapacity,
+// ErrItemTooLarge or ErrNotAdmitted.
+func (c *Cache[K, V]) Set(key K, value V, duration time.Duration) error {
+	return c.set("", key, value, duration, nil, "")
+}
+
+func (c *Cache[K, V]) set(principal string, key K, value V, duration time.Duration, priority *int, owner string) error {
+	defer c.slowKeyOp("set", key, c.slowStart())
+
+	if err := c.writable(); err != nil {
+		return err
+	}
+	if c.size <= 0 {
+		return c.misuse(ErrZeroCapacity)
 	}
+	c.checkTTL(duration)
 
-	return time.Now().Add(duration)
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	switch c.put(principal, key, value, duration) {
+	case SetTooLarge:
+		return ErrItemTooLarge
+	case SetNotAdmitted:
+		return ErrNotAdmitted
+	}
+	if item, ok := c.items[key]; ok {
+		item.owner = owner
+		if priority != nil {
+			c.prioritize(item, *priority)
+		}
+	}
+
+	return nil
 }
 
-func (c *InMemoryCache) Set(key string, value interface{}, duration time.Duration) {
-	if c.size <= 0 {
-		return
+func (c *Cache[K, V]) put(principal string, key K, value V, duration time.Duration) SetResult {
+	if c.valueCost(value) > c.size {
+		return SetTooLarge
 	}
 
-	c.Lock()
-	defer c.Unlock()
+	c.recordAccess(key)
 
-	newItem := Item{
-		Value:      value,
-		Expiration: c.getExp(duration),
-		Frequency:  0,
+	item, found := c.items[key]
+	if found && c.suppressed(item, value) {
+		return SetUnchanged
+	}
+	if !found && !c.admit(key, c.valueCost(value)) {
+		return SetNotAdmitted
 	}
 
-	if _, ok := c.items[key]; ok {
-		newItem.Frequency = c.items[key].Frequency
-		c.upgradeItem(newItem, key)
-		return
+	c.audit(principal, auditSet, key, &value)
+
+	if found {
+		c.cost -= item.cost()
+		item.history = c.pushHistory(item.history, item.Value, value)
+		item.Value = c.intern(value)
+		item.owner = ""
+		c.seal(item)
+		c.weigh(item)
+		c.setExp(item, duration)
+		c.cost += item.cost()
+		c.upgradeItem(item, key)
+		c.publish(key, item)
+		c.evict(0, key)
+		c.checkWatermark()
+		c.counters.sets.Add(1)
+		return SetReplaced
 	}
 
-	if len(c.items) >= c.size {
-		for keyToDelete := range c.freqGroup[c.minFreq] {
-			c.deleteItemInGroup(c.items[keyToDelete], keyToDelete)
-			delete(c.items, keyToDelete)
-			break
-		}
+	item = &Item[K, V]{
+		Value:   value,
+		key:     key,
+		created: c.clock.Now(),
 	}
+	if freq, ok := c.freqHints[key]; ok {
+		item.Frequency = freq
+		delete(c.freqHints, key)
+	}
+	c.weigh(item)
+	c.setExp(item, duration)
+
+	c.dropOverflow(key)
+	c.insertItem(item, key)
+	c.seal(item)
+	c.counters.sets.Add(1)
+
+	return SetStored
+}
+
+func (c *Cache[K, V]) insertItem(item *Item[K, V], key K) {
+	c.evict(item.cost(), key)
+
+	c.insertions++
+	item.inserted = c.insertions
+	if item.priority != 0 {
+		c.prioritized++
+	}
+	item.Frequency++
+	c.place(item, nil)
+	c.trackExpiry(item)
+	if c.policy != nil {
+		c.policy.OnAdd(key)
+	}
+	c.countOp()
+
+	c.internItem(item)
+	c.filterAdd(key)
+	c.items[key] = item
+	c.cost += item.cost()
+	c.indexPath(key)
+	c.publish(key, item)
+	c.checkWatermark()
+}
 
-	c.freqGroup[newItem.Frequency] = make(map[string]struct{})
-	c.freqGroup[newItem.Frequency][key] = struct{}{}
-	c.minFreq = newItem.Frequency
-	c.upgradeItem(newItem, key)
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	value, _, found := c.GetStale(key)
+
+	return value, found
 }
 
-func (c *InMemoryCache) Get(key string) (interface{}, bool) {
+func (c *Cache[K, V]) getLocked(key K) (V, bool, bool) {
+	if value, stale, found, ok := c.getShared(key); ok {
+		return value, stale, found
+	}
+
+	c.lock(opGet)
+
+	defer c.unlock()
 
-	c.Lock()
+	c.drainHits()
 
-	defer c.Unlock()
+	return c.lookup(key)
+}
 
+// lookup is the locked part of Get.
+func (c *Cache[K, V]) lookup(key K) (V, bool, bool) {
 	item, found := c.items[key]
 
 	if !found {
-		return nil, false
+		value, found := c.promoteOverflow(key)
+		return value, false, found
 	}
 
-	if item.isExpired() {
-		return nil, false
+	if c.isExpired(item) {
+		if c.inGrace(item.Expiration, item.deadline) {
+			return c.graceRead(item.Value)
+		}
+		c.removeItem(item, key, ReasonExpired)
+		var zero V
+		return zero, false, false
 	}
 
 	c.upgradeItem(item, key)
 
-	return item.Value, true
+	return item.Value, false, true
 }
 
-func (c *InMemoryCache) upgradeItem(item Item, key string) {
-	c.items[key] = item
-	newFreq := item.Frequency + 1
-	if c.deleteItemInGroup(item, key) {
-		c.minFreq = newFreq
+func (c *Cache[K, V]) upgradeItem(item *Item[K, V], key K) {
+	item.Frequency++
+	c.move(item)
+	if c.policy != nil {
+		c.policy.OnAccess(key)
 	}
-	item.Frequency = newFreq
-	if _, ok := c.freqGroup[item.Frequency]; !ok {
-		c.freqGroup[item.Frequency] = make(map[string]struct{})
+	c.emitHot(key, item.Frequency)
+	c.countOp()
+}
+
+func (c *Cache[K, V]) Delete(key K) error {
+	return c.delete("", key)
+}
+
+func (c *Cache[K, V]) delete(principal string, key K) error {
+	_, _, err := c.deleteGet(principal, key)
+
+	return err
+}
+
+// DeleteGet removes key and returns the value it held, without affecting
+// frequencies. An expired entry is removed but reported as ErrKeyNotFound.
+func (c *Cache[K, V]) DeleteGet(key K) (V, error) {
+	value, live, err := c.deleteGet("", key)
+	if err == nil && !live {
+		err = ErrKeyNotFound
 	}
-	c.freqGroup[item.Frequency][key] = struct{}{}
-	c.items[key] = item
+
+	return value, err
 }
 
-func (c *InMemoryCache) Delete(key string) error {
-	c.Lock()
-	defer c.Unlock()
+func (c *Cache[K, V]) deleteGet(principal string, key K) (value V, live bool, err error) {
+	defer c.slowKeyOp("delete", key, c.slowStart())
 
-	var item Item
-	var found bool
-	if item, found = c.items[key]; !found {
-		return errors.New("Key not found")
+	if err := c.writable(); err != nil {
+		return value, false, err
 	}
 
-	delete(c.items, key)
+	c.lock(opDelete)
+	defer c.unlock()
+
+	c.drainHits()
 
-	if c.deleteItemInGroup(item, key) {
-		c.minFreq = c.findNewMinFreq()
+	item, found := c.items[key]
+	if !found && c.overflow != nil {
+		if element, ok := c.overflow.entries[key]; ok {
+			item, found = c.overflow.item(element), true
+			c.evictOverflow(key, ReasonDelete)
+		}
+	} else if found {
+		c.removeItem(item, key, ReasonDelete)
+	}
+	if !found {
+		return value, false, ErrKeyNotFound
 	}
 
-	return nil
+	c.audit(principal, auditDelete, key, nil)
+
+	if c.isExpired(item) {
+		return value, false, nil
+	}
+
+	return item.Value, true, nil
 }
 
-func (c *InMemoryCache) deleteItemInGroup(item Item, key string) (minChanged bool) {
-	delete(c.freqGroup[item.Frequency], key)
-	if len(c.freqGroup[item.Frequency]) == 0 {
-		delete(c.freqGroup, item.Frequency)
-		return c.minFreq == item.Frequency
+func (c *Cache[K, V]) evict(cost int, spare K) {
+	if c.cost+cost > c.size {
+		c.removeReapable()
+	}
+
+	for c.cost+cost > c.size {
+		keyToDelete, ok := c.victim(spare)
+		if !ok {
+			return
+		}
+		item := c.items[keyToDelete]
+		c.removeItem(item, keyToDelete, ReasonCapacity)
+		c.pushOverflow(item, keyToDelete)
 	}
-	return false
 }
 
-func (c *InMemoryCache) findNewMinFreq() uint64 {
-	minFreq := uint64(math.MaxUint64)
-	for cur, _ := range c.freqGroup {
-		if minFreq > cur {
-			minFreq = cur
+func (c *Cache[K, V]) victim(spare K) (K, bool) {
+	if c.policy != nil {
+		return c.policy.Victim(spare)
+	}
+
+	for b := c.lowest; b != nil; b = b.next {
+		if item := c.pick(b, spare); item != nil {
+			return item.key, true
 		}
 	}
 
-	return minFreq
+	var zero K
+	return zero, false
 }
 
-func (c *InMemoryCache) Update(isUpdated func(v interface{}) bool, update func(v interface{}), duration time.Duration) {
-	c.Lock()
-	defer c.Unlock()
+func (c *Cache[K, V]) removeItem(item *Item[K, V], key K, reason EvictionReason) {
+	c.verify(item, key, reason)
+	c.invalidateLinked(key, reason)
+	c.emitRemoval(item, key, reason)
+	c.countRemoval(reason)
+	if reason != ReasonCapacity || c.overflow == nil {
+		c.evicted(key, item.Value, reason)
+	}
+	c.releaseItem(item)
+	delete(c.items, key)
+	c.filterRemove(key)
+	c.unpublish(key)
+	c.unindexPath(key)
+	c.cost -= item.cost()
+	c.unplace(item)
+	c.untrackExpiry(item)
+	if item.priority != 0 {
+		c.prioritized--
+	}
+	if c.policy != nil {
+		c.policy.OnRemove(key)
+	}
+	if reason != ReasonCapacity {
+		c.checkWatermark()
+	}
+}
 
-	exp := c.getExp(duration)
+func (c *Cache[K, V]) Update(isUpdated func(v V) bool, update func(v V), duration time.Duration) {
+	defer c.slowOp("update", c.slowStart())
+
+	if c.writable() != nil || c.checkCallbacks(isUpdated == nil || update == nil) != nil {
+		return
+	}
+	c.checkTTL(duration)
+
+	c.lock(opUpdate)
+	defer c.unlock()
+
+	c.drainHits()
 
 	for key, item := range c.items {
-		if isUpdated(item.Value) && !item.isExpired() {
+		if isUpdated(item.Value) && !c.isExpired(item) {
 			update(item.Value)
-			item.Expiration = exp
+			c.seal(item)
+			c.audit("", auditUpdate, key, &item.Value)
+			c.setExp(item, duration)
 			c.upgradeItem(item, key)
+			c.publish(key, item)
 		}
 	}
 }
 
-func (c *InMemoryCache) startGC() {
-	for range time.Tick(c.cleanupInterval) {
-		c.Lock()
+func (c *Cache[K, V]) startGC(ticker Ticker) {
+	defer c.workers.Done()
+	defer ticker.Stop()
 
-		var isFindMin bool
-		for key, item := range c.items {
-			if item.isExpired() {
-				delete(c.items, key)
-				isFindMin = c.deleteItemInGroup(item, key) || isFindMin
-			}
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C():
 		}
 
-		if isFindMin {
-			c.minFreq = c.findNewMinFreq()
+		c.sweep()
+		ticker.Done()
+	}
+}
+
+func (c *Cache[K, V]) sweep() {
+	defer c.slowOp("sweep", c.slowStart())
+
+	report := SweepReport{Started: c.clock.Now()}
+	if c.preSweep != nil {
+		report.Len = c.Stats().Len
+		c.preSweep(report)
+	}
+
+	c.lock(opSweep)
+	c.drainHits()
+	if c.activeSamples > 0 {
+		report.Removed, report.Reclaimed = c.sampleExpired()
+	} else {
+		report.Removed, report.Reclaimed = c.removeExpired(true)
+	}
+	report.Len = len(c.items)
+	c.unlock()
+
+	report.Duration = c.clock.Now().Sub(report.Started)
+	if c.postSweep != nil {
+		c.postSweep(report)
+	}
+}
+
+func (c *Cache[K, V]) removeExpired(purgeCold bool) (removed, reclaimed int) {
+	now := c.clock.Now()
+
+	for key, item := range c.items {
+		reason := ReasonExpired
+		if !c.isReapable(item) {
+			if !purgeCold || !c.isCold(item, now) {
+				continue
+			}
+			reason = ReasonCold
 		}
 
-		c.Unlock()
+		c.removeItem(item, key, reason)
+		removed++
+		reclaimed += item.cost()
 	}
+
+	removed += c.removeExpiredOverflow()
+
+	return removed, reclaimed
 }
@@ -0,0 +1,71 @@
+package lfu
+
+import "time"
+
+// EntryInfo describes a key without affecting it. TTL is the time left
+// until expiry and is only meaningful when Present or Expired is set.
+// Expired entries have not been removed yet; Overflow entries sit in the
+// overflow tier and would be promoted by a Get. Level is the entry's
+// frequency level under WithFrequencyLevels and zero otherwise.
+type EntryInfo struct {
+	Present   bool
+	Expired   bool
+	Overflow  bool
+	TTL       time.Duration
+	Frequency uint64
+	Level     int
+}
+
+// InspectMany reports on every key in keys under a single lock acquisition
+// without changing frequencies or expirations.
+func (c *Cache[K, V]) InspectMany(keys []K) map[K]EntryInfo {
+	infos := make(map[K]EntryInfo, len(keys))
+	if c.usable() != nil {
+		for _, key := range keys {
+			infos[key] = EntryInfo{}
+		}
+		return infos
+	}
+
+	c.lock(opGet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	for _, key := range keys {
+		item, found := c.items[key]
+		overflow := false
+		if !found && c.overflow != nil {
+			if element, ok := c.overflow.entries[key]; ok {
+				item, found, overflow = c.overflow.item(element), true, true
+			}
+		}
+		if !found {
+			infos[key] = EntryInfo{}
+			continue
+		}
+
+		expired := c.isExpired(item)
+		info := EntryInfo{
+			Present:   !expired,
+			Expired:   expired,
+			Overflow:  overflow,
+			TTL:       c.remaining(item),
+			Frequency: item.Frequency,
+		}
+		if len(c.levels) > 0 {
+			info.Level = int(c.level(item.Frequency))
+		}
+		infos[key] = info
+	}
+
+	return infos
+}
+
+func (c *Cache[K, V]) remaining(item *Item[K, V]) time.Duration {
+	if c.monotonic {
+		return item.deadline - c.clock.Monotonic()
+	}
+
+	return item.Expiration.Sub(c.clock.Now())
+}
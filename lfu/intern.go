@@ -0,0 +1,140 @@
+package lfu
+
+import (
+	"bytes"
+	"hash/maphash"
+)
+
+type internEntry struct {
+	value interface{}
+	refs  int
+}
+
+type internTable struct {
+	seed    maphash.Seed
+	entries map[uint64][]*internEntry
+}
+
+// WithValueInterning stores string and []byte values once per distinct
+// content: a Set whose value equals one already cached keeps a reference to
+// the existing copy and lets the new one be collected. Interned values are
+// shared between keys and must not be modified in place.
+func WithValueInterning() Option {
+	return func(c *settings) {
+		c.interned = &internTable{
+			seed:    maphash.MakeSeed(),
+			entries: make(map[uint64][]*internEntry),
+		}
+	}
+}
+
+func (t *internTable) hash(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case string:
+		return maphash.String(t.seed, v), true
+	case []byte:
+		return maphash.Bytes(t.seed, v), true
+	}
+
+	return 0, false
+}
+
+func internEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		return ok && a == b
+	case []byte:
+		b, ok := b.([]byte)
+		return ok && bytes.Equal(a, b)
+	}
+
+	return false
+}
+
+// intern returns the canonical copy of value and takes a reference to it.
+func (c *Cache[K, V]) intern(value V) V {
+	if c.interned == nil {
+		return value
+	}
+
+	boxed := any(value)
+	h, ok := c.interned.hash(boxed)
+	if !ok {
+		return value
+	}
+
+	for _, entry := range c.interned.entries[h] {
+		if internEqual(entry.value, boxed) {
+			entry.refs++
+			return entry.value.(V)
+		}
+	}
+	c.interned.entries[h] = append(c.interned.entries[h], &internEntry{value: boxed, refs: 1})
+
+	return value
+}
+
+// release drops a reference taken by intern and reports whether value is
+// no longer used by any entry.
+func (c *Cache[K, V]) release(value V) bool {
+	if c.interned == nil {
+		return true
+	}
+
+	boxed := any(value)
+	h, ok := c.interned.hash(boxed)
+	if !ok {
+		return true
+	}
+
+	entries := c.interned.entries[h]
+	for i, entry := range entries {
+		if !internEqual(entry.value, boxed) {
+			continue
+		}
+
+		entry.refs--
+		if entry.refs > 0 {
+			return false
+		}
+
+		entries[i] = entries[len(entries)-1]
+		entries[len(entries)-1] = nil
+		if entries = entries[:len(entries)-1]; len(entries) == 0 {
+			delete(c.interned.entries, h)
+		} else {
+			c.interned.entries[h] = entries
+		}
+		return true
+	}
+
+	return true
+}
+
+func (c *Cache[K, V]) internItem(item *Item[K, V]) {
+	item.Value = c.intern(item.Value)
+	for i, old := range item.history {
+		item.history[i] = c.intern(old)
+	}
+}
+
+func (c *Cache[K, V]) releaseItem(item *Item[K, V]) {
+	if c.interned == nil {
+		return
+	}
+
+	c.release(item.Value)
+	for _, old := range item.history {
+		c.release(old)
+	}
+}
+
+// discard is called for values an overwrite pushed out of the cache. A
+// value sharing memory with current, the value now cached, is not
+// recycled.
+func (c *Cache[K, V]) discard(value, current V) {
+	if c.release(value) && c.recycler != nil && !sharesMemory(any(value), any(current)) {
+		c.recycle(value)
+	}
+}
@@ -0,0 +1,85 @@
+package lfu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	keyDelimiter = ':'
+	keyEscape    = '\\'
+)
+
+var errMalformedKey = errors.New("Malformed composite key")
+
+// KeyBuilder accumulates the parts of a composite key. Parts are formatted
+// with fmt.Sprint and escaped so that no two different part lists produce
+// the same key.
+type KeyBuilder []string
+
+func (b KeyBuilder) With(parts ...interface{}) KeyBuilder {
+	next := make(KeyBuilder, len(b), len(b)+len(parts))
+	copy(next, b)
+
+	for _, part := range parts {
+		next = append(next, fmt.Sprint(part))
+	}
+
+	return next
+}
+
+func (b KeyBuilder) String() string {
+	var sb strings.Builder
+	for i, part := range b {
+		if i > 0 {
+			sb.WriteByte(keyDelimiter)
+		}
+		for j := 0; j < len(part); j++ {
+			if part[j] == keyDelimiter || part[j] == keyEscape {
+				sb.WriteByte(keyEscape)
+			}
+			sb.WriteByte(part[j])
+		}
+	}
+
+	return sb.String()
+}
+
+// Key builds a canonical composite key, e.g. Key("user", 42, "prefs").
+func Key(parts ...interface{}) string {
+	return KeyBuilder(nil).With(parts...).String()
+}
+
+// ParseKey splits a key produced by Key back into its unescaped parts.
+func ParseKey(key string) ([]string, error) {
+	var parts []string
+	var sb strings.Builder
+
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case keyEscape:
+			i++
+			if i == len(key) {
+				return nil, errMalformedKey
+			}
+			sb.WriteByte(key[i])
+		case keyDelimiter:
+			parts = append(parts, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(key[i])
+		}
+	}
+
+	return append(parts, sb.String()), nil
+}
+
+// keyText is the form a cache key takes in audit records and events.
+func keyText[K comparable](key K) string {
+	if s, ok := any(key).(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(key)
+}
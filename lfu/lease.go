@@ -0,0 +1,176 @@
+package lfu
+
+import "time"
+
+const defaultLeaseTimeout = 10 * time.Second
+
+type lease struct {
+	token   uint64
+	granted time.Time
+	done    chan struct{}
+	waiters int
+}
+
+// Lease is the right to rebuild one missing key, granted by GetLeased to a
+// single caller at a time. The holder must call Fill or Release.
+type Lease[K comparable, V any] struct {
+	c     *Cache[K, V]
+	key   K
+	token uint64
+}
+
+// LeaseState describes an outstanding lease.
+type LeaseState struct {
+	Granted time.Time
+	Waiters int
+}
+
+// WithLeaseTimeout sets how long a lease granted by GetLeased is honored
+// before it is considered abandoned and granted again. It defaults to ten
+// seconds.
+func WithLeaseTimeout(d time.Duration) Option {
+	return func(c *settings) {
+		if d > 0 {
+			c.leaseTimeout = d
+		}
+	}
+}
+
+// GetLeased is Get that protects a missing key from a stampede of
+// rebuilders. A live value is returned as usual. On a miss the first caller
+// receives a lease, along with the expired value if the entry is still
+// present. While the lease is held, other callers receive that expired
+// value marked stale, or, if there is none, wait up to wait for the lease to
+// end and then look again; if it has not ended by then they get
+// ErrLeaseTimeout. Combine with WithExpiredReadGrace to keep expired values
+// available for longer.
+func (c *Cache[K, V]) GetLeased(key K, wait time.Duration) (value V, stale bool, lease *Lease[K, V], err error) {
+	if err = c.usable(); err != nil {
+		return value, false, nil, err
+	}
+
+	deadline := c.clock.Monotonic() + wait
+	for {
+		c.lock(opGet)
+		if err = c.usable(); err != nil {
+			c.unlock()
+			return value, false, nil, err
+		}
+		c.drainHits()
+
+		item, found := c.items[key]
+		if !found {
+			if v, ok := c.promoteOverflow(key); ok {
+				c.unlock()
+				c.countLookup(true)
+				return v, false, nil, nil
+			}
+		}
+		if found && !c.isExpired(item) {
+			c.upgradeItem(item, key)
+			value = item.Value
+			c.unlock()
+			c.countLookup(true)
+			return value, false, nil, nil
+		}
+		if found {
+			value, stale = item.Value, true
+		}
+
+		held, ok := c.leases[key]
+		if ok && c.clock.Now().Sub(held.granted) >= c.leaseTimeout {
+			c.endLease(key, held)
+			ok = false
+		}
+		if !ok {
+			lease = c.grantLease(key)
+			c.unlock()
+			c.countLookup(false)
+			return value, stale, lease, nil
+		}
+		if stale {
+			c.unlock()
+			c.countLookup(false)
+			return value, true, nil, nil
+		}
+
+		held.waiters++
+		c.unlock()
+
+		timer := c.newTimer(deadline - c.clock.Monotonic())
+		var expired bool
+		select {
+		case <-held.done:
+		case <-timer.C():
+			expired = true
+		}
+		timer.Stop()
+
+		c.lock(opGet)
+		held.waiters--
+		c.unlock()
+
+		if expired {
+			c.countLookup(false)
+			return value, false, nil, ErrLeaseTimeout
+		}
+	}
+}
+
+func (c *Cache[K, V]) grantLease(key K) *Lease[K, V] {
+	if c.leases == nil {
+		c.leases = make(map[K]*lease)
+	}
+
+	c.leaseToken++
+	c.leases[key] = &lease{
+		token:   c.leaseToken,
+		granted: c.clock.Now(),
+		done:    make(chan struct{}),
+	}
+
+	return &Lease[K, V]{c: c, key: key, token: c.leaseToken}
+}
+
+func (c *Cache[K, V]) endLease(key K, l *lease) {
+	delete(c.leases, key)
+	close(l.done)
+}
+
+// LeaseState reports the outstanding lease on key, if any.
+func (c *Cache[K, V]) LeaseState(key K) (LeaseState, bool) {
+	if c.usable() != nil {
+		return LeaseState{}, false
+	}
+
+	c.lock(opStats)
+	defer c.unlock()
+
+	l, found := c.leases[key]
+	if !found {
+		return LeaseState{}, false
+	}
+
+	return LeaseState{Granted: l.granted, Waiters: l.waiters}, true
+}
+
+// Fill stores the rebuilt value and releases the lease.
+func (l *Lease[K, V]) Fill(value V, ttl time.Duration) error {
+	err := l.c.Set(l.key, value, ttl)
+	l.Release()
+
+	return err
+}
+
+// Release gives the lease up without storing a value, waking the waiting
+// callers so one of them can take it over. Releasing a lease that has timed
+// out does nothing.
+func (l *Lease[K, V]) Release() {
+	c := l.c
+	c.lock(opSet)
+	defer c.unlock()
+
+	if held, ok := c.leases[l.key]; ok && held.token == l.token {
+		c.endLease(l.key, held)
+	}
+}
@@ -0,0 +1,52 @@
+package lfu
+
+import "sort"
+
+// WithFrequencyLevels groups entries into coarse frequency levels for
+// eviction: thresholds lists, in ascending order, the access counts at
+// which an entry reaches levels 0, 1, 2 and so on, so 1, 4, 16, 64 maps
+// 1-3 accesses to level 0 and 64 or more to level 3. Entries below the
+// first threshold count as level 0. Capacity eviction picks from the lowest
+// level, least recently promoted or accessed first. Frequencies are still
+// counted exactly and reported as such.
+func WithFrequencyLevels(thresholds ...uint64) Option {
+	return func(c *settings) {
+		levels := make([]uint64, 0, len(thresholds))
+		for i, t := range thresholds {
+			if t == 0 || (i > 0 && t <= thresholds[i-1]) {
+				return
+			}
+			levels = append(levels, t)
+		}
+		c.levels = levels
+	}
+}
+
+// level maps a frequency to the level of its bucket: the frequency itself,
+// or its level when levels are configured.
+func (c *Cache[K, V]) level(freq uint64) uint64 {
+	if len(c.levels) == 0 {
+		return freq
+	}
+
+	if n := sort.Search(len(c.levels), func(i int) bool { return c.levels[i] > freq }); n > 0 {
+		return uint64(n - 1)
+	}
+
+	return 0
+}
+
+// levelCounts returns the number of entries at every level, or nil without
+// levels.
+func (c *Cache[K, V]) levelCounts() []int {
+	if len(c.levels) == 0 {
+		return nil
+	}
+
+	counts := make([]int, len(c.levels))
+	for b := c.lowest; b != nil; b = b.next {
+		counts[b.level] = b.size
+	}
+
+	return counts
+}
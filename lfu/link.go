@@ -0,0 +1,38 @@
+package lfu
+
+import cache "github.com/grrrance/lfu-in-memory"
+
+type link[K comparable] struct {
+	other  cache.InMemoryLFU
+	mapKey func(key K) []string
+}
+
+// LinkInvalidation makes every key deleted from or expired in c also delete
+// the keys mapKey derives from it in other. Capacity evictions are not
+// propagated, since the evicted data did not change. The deletes run after
+// c's lock is released, on the goroutine that removed the key.
+func (c *Cache[K, V]) LinkInvalidation(other cache.InMemoryLFU, mapKey func(key K) []string) {
+	if c.writable() != nil || c.checkCallbacks(mapKey == nil) != nil || other == nil {
+		return
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.links = append(c.links, link[K]{other: other, mapKey: mapKey})
+}
+
+func (c *Cache[K, V]) invalidateLinked(key K, reason EvictionReason) {
+	if len(c.links) == 0 || reason != ReasonDelete && reason != ReasonExpired {
+		return
+	}
+
+	for _, l := range c.links {
+		l := l
+		c.afterUnlock(func() {
+			for _, derived := range l.mapKey(key) {
+				_ = l.other.Delete(derived)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestLinkInvalidationFromOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove func(c *lfu.Cache[string, int])
+	}{
+		{"Delete", func(c *lfu.Cache[string, int]) { c.Delete("a") }},
+		{"InvalidateSubtree", func(c *lfu.Cache[string, int]) { c.InvalidateSubtree("a") }},
+		{"Flush", func(c *lfu.Cache[string, int]) { c.Flush() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newClockCache(t, 1, 0, lfu.WithOverflow(4))
+			derived := lfu.NewInMemoryCache(4, time.Hour, 0)
+			t.Cleanup(func() { derived.Shutdown(context.Background()) })
+			c.LinkInvalidation(derived, func(key string) []string { return []string{"derived:" + key} })
+
+			c.Set("a", 1, time.Hour)
+			c.Set("b", 2, time.Hour)
+			derived.Set("derived:a", 1, time.Hour)
+
+			tt.remove(c)
+
+			if _, ok := derived.Get("derived:a"); ok {
+				t.Fatal("removing an overflowed key left its derived key in the linked cache")
+			}
+		})
+	}
+}
+
+func TestLinkInvalidationOnOverflowExpiry(t *testing.T) {
+	c, clock := newClockCache(t, 1, 0, lfu.WithOverflow(4))
+	derived := lfu.NewInMemoryCache(4, time.Hour, 0)
+	t.Cleanup(func() { derived.Shutdown(context.Background()) })
+	c.LinkInvalidation(derived, func(key string) []string { return []string{"derived:" + key} })
+
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Hour)
+	derived.Set("derived:a", 1, time.Hour)
+
+	clock.Advance(2 * time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expired key was served from overflow")
+	}
+
+	if _, ok := derived.Get("derived:a"); ok {
+		t.Fatal("expiry of an overflowed key left its derived key in the linked cache")
+	}
+}
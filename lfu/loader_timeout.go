@@ -0,0 +1,34 @@
+package lfu
+
+import (
+	"context"
+	"time"
+)
+
+// WithLoaderTimeout bounds every loader call made by the cache, in
+// GetOrLoad, Coalescer batches and scheduled namespace refreshes, to d of
+// real time, so a hung origin cannot hold a caller or background worker
+// forever. Loaders must honor their context for this to take effect.
+// Calls that fail once their context has timed out are counted in
+// Stats.LoaderTimeouts.
+func WithLoaderTimeout(d time.Duration) Option {
+	return func(c *settings) {
+		if d > 0 {
+			c.loaderTimeout = d
+		}
+	}
+}
+
+func (c *Cache[K, V]) loaderContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.loaderTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, c.loaderTimeout)
+}
+
+func (c *Cache[K, V]) countLoaderTimeout(ctx context.Context, err error) {
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		c.counters.loaderTimeouts.Add(1)
+	}
+}
@@ -0,0 +1,135 @@
+package lfu
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type lockOp int
+
+const (
+	opGet lockOp = iota
+	opSet
+	opDelete
+	opUpdate
+	opTouch
+	opSweep
+	opStats
+	opShutdown
+	lockOpCount
+)
+
+var lockOpNames = [lockOpCount]string{
+	opGet:      "get",
+	opSet:      "set",
+	opDelete:   "delete",
+	opUpdate:   "update",
+	opTouch:    "touch",
+	opSweep:    "sweep",
+	opStats:    "stats",
+	opShutdown: "shutdown",
+}
+
+// LockWaitBuckets are the upper bounds of the lock wait histogram buckets in
+// LockStats.WaitHistogram. The final histogram bucket counts every wait
+// longer than the last bound.
+var LockWaitBuckets = [...]time.Duration{
+	time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+type LockStats struct {
+	Acquisitions  uint64
+	Contended     uint64
+	WaitTotal     time.Duration
+	WaitHistogram [len(LockWaitBuckets) + 1]uint64
+}
+
+type lockCounters struct {
+	acquisitions atomic.Uint64
+	contended    atomic.Uint64
+	waitTotal    atomic.Int64
+	histogram    [len(LockWaitBuckets) + 1]atomic.Uint64
+}
+
+func (c *Cache[K, V]) lock(op lockOp) {
+	counters := &c.lockStats[op]
+	counters.acquisitions.Add(1)
+
+	if c.TryLock() {
+		return
+	}
+
+	start := time.Now()
+	c.Lock()
+	counters.recordWait(time.Since(start))
+}
+
+func (counters *lockCounters) recordWait(wait time.Duration) {
+	counters.contended.Add(1)
+	counters.waitTotal.Add(int64(wait))
+
+	bucket := len(LockWaitBuckets)
+	for i, bound := range LockWaitBuckets {
+		if wait <= bound {
+			bucket = i
+			break
+		}
+	}
+	counters.histogram[bucket].Add(1)
+}
+
+// rlock is lock for the shared side of the lock. Work must not be queued
+// with afterUnlock while only the read lock is held.
+func (c *Cache[K, V]) rlock(op lockOp) {
+	counters := &c.lockStats[op]
+	counters.acquisitions.Add(1)
+
+	if c.TryRLock() {
+		return
+	}
+
+	start := time.Now()
+	c.RLock()
+	counters.recordWait(time.Since(start))
+}
+
+func (c *Cache[K, V]) lockSnapshot() map[string]LockStats {
+	snapshot := make(map[string]LockStats, lockOpCount)
+	for op := range c.lockStats {
+		counters := &c.lockStats[op]
+
+		stats := LockStats{
+			Acquisitions: counters.acquisitions.Load(),
+			Contended:    counters.contended.Load(),
+			WaitTotal:    time.Duration(counters.waitTotal.Load()),
+		}
+		for i := range counters.histogram {
+			stats.WaitHistogram[i] = counters.histogram[i].Load()
+		}
+
+		snapshot[lockOpNames[op]] = stats
+	}
+
+	return snapshot
+}
+
+// unlock releases the lock and then runs the work deferred with
+// afterUnlock, on the calling goroutine, in the order it was queued.
+func (c *Cache[K, V]) unlock() {
+	pending := c.pending
+	c.pending = nil
+	c.Unlock()
+
+	for _, fn := range pending {
+		fn()
+	}
+}
+
+func (c *Cache[K, V]) afterUnlock(fn func()) {
+	c.pending = append(c.pending, fn)
+}
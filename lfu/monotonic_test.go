@@ -0,0 +1,54 @@
+package lfu_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestMonotonicExpiryIgnoresForwardWallStep(t *testing.T) {
+	c, clock := newClockCache(t, 4, 0, lfu.WithMonotonicExpiry())
+
+	c.Set("a", 1, time.Minute)
+	clock.Step(time.Hour)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("entry expired early after a forward wall-clock step")
+	}
+
+	clock.Advance(2 * time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("entry outlived its TTL")
+	}
+}
+
+func TestMonotonicExpiryIgnoresBackwardWallStep(t *testing.T) {
+	c, clock := newClockCache(t, 4, 0, lfu.WithMonotonicExpiry())
+
+	c.Set("a", 1, time.Minute)
+	clock.Step(-time.Hour)
+	clock.Advance(2 * time.Minute)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("entry was kept alive past its TTL by a backward wall-clock step")
+	}
+}
+
+func TestWallExpiryFollowsWallStep(t *testing.T) {
+	c, clock := newClockCache(t, 4, 0)
+
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+	clock.Step(time.Hour)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("without WithMonotonicExpiry a forward step should expire the entry")
+	}
+
+	clock.Step(-2 * time.Hour)
+	clock.Advance(2 * time.Minute)
+	if _, ok := c.Get("b"); !ok {
+		t.Fatal("without WithMonotonicExpiry a backward step should extend the entry")
+	}
+}
@@ -0,0 +1,61 @@
+package lfu
+
+import "time"
+
+// ValueWithTTL is one write for MSet. A non-positive TTL selects the
+// default expiration.
+type ValueWithTTL[V any] struct {
+	Value V
+	TTL   time.Duration
+}
+
+// MGet looks up keys under a single lock acquisition, raising the
+// frequency of every hit as Get does. It returns the values found and the
+// keys that missed, in the order given.
+func (c *Cache[K, V]) MGet(keys []K) (found map[K]V, misses []K) {
+	defer c.slowOp("mget", c.slowStart())
+
+	found = make(map[K]V, len(keys))
+	if c.usable() != nil {
+		return found, append(misses, keys...)
+	}
+
+	c.lock(opGet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	for _, key := range keys {
+		c.recordAccess(key)
+
+		var value V
+		var ok bool
+		if c.filterMayContain(key) {
+			value, _, ok = c.lookup(key)
+		}
+		c.countLookup(ok)
+
+		if ok {
+			found[key] = value
+		} else {
+			misses = append(misses, key)
+		}
+	}
+
+	return found, misses
+}
+
+// MSet is SetMany for a map of entries, written in no particular order.
+func (c *Cache[K, V]) MSet(entries map[K]ValueWithTTL[V]) map[K]SetResult {
+	batch := make([]Entry[K, V], 0, len(entries))
+	for key, entry := range entries {
+		batch = append(batch, Entry[K, V]{Key: key, Value: entry.Value, TTL: entry.TTL})
+	}
+
+	results := make(map[K]SetResult, len(batch))
+	for i, result := range c.SetMany(batch) {
+		results[batch[i].Key] = result
+	}
+
+	return results
+}
@@ -0,0 +1,137 @@
+package lfu
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"log/slog"
+	"maps"
+	"math"
+	"reflect"
+)
+
+// WithMutationCheck is a debugging aid that checksums every stored value
+// and verifies the checksum when the entry is removed, logging an error if
+// the value was modified in place after being cached, for example through
+// a pointer or slice returned by Get. Update is expected to modify values
+// and reseals them. Checksumming walks the whole value with reflection, so
+// leave this off in production. A nil logger selects slog.Default.
+func WithMutationCheck(logger *slog.Logger) Option {
+	return func(c *settings) {
+		if logger == nil {
+			logger = slog.Default()
+		}
+		c.mutationLogger = logger
+		c.mutationSeed = maphash.MakeSeed()
+	}
+}
+
+func (c *Cache[K, V]) seal(item *Item[K, V]) {
+	if c.mutationLogger != nil {
+		item.checksum = c.checksum(item.Value)
+	}
+}
+
+func (c *Cache[K, V]) verify(item *Item[K, V], key K, reason EvictionReason) {
+	if c.mutationLogger == nil || c.checksum(item.Value) == item.checksum {
+		return
+	}
+
+	c.mutationLogger.Error("lfu: cached value was modified in place",
+		"cache", c.name, "key", keyText(key), "reason", reason.String())
+}
+
+func (c *Cache[K, V]) checksum(value V) uint64 {
+	var h maphash.Hash
+	h.SetSeed(c.mutationSeed)
+	hashValue(&h, reflect.ValueOf(&value).Elem(), make(map[uintptr]bool))
+
+	return h.Sum64()
+}
+
+func hashUint(h *maphash.Hash, n uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	h.Write(buf[:])
+}
+
+// hashValue feeds everything reachable from v into h. Pointers already
+// visited contribute only their address, which stops cycles.
+func hashValue(h *maphash.Hash, v reflect.Value, seen map[uintptr]bool) {
+	hashUint(h, uint64(v.Kind()))
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			hashUint(h, 1)
+		} else {
+			hashUint(h, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		hashUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		hashUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		hashUint(h, math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		hashUint(h, math.Float64bits(real(v.Complex())))
+		hashUint(h, math.Float64bits(imag(v.Complex())))
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i), seen)
+		}
+	case reflect.Slice:
+		hashUint(h, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i), seen)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i), seen)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			h.WriteString(v.Elem().Type().String())
+			hashValue(h, v.Elem(), seen)
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		p := v.Pointer()
+		hashUint(h, uint64(p))
+		if !seen[p] {
+			seen[p] = true
+			hashValue(h, v.Elem(), seen)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		p := v.Pointer()
+		if seen[p] {
+			hashUint(h, uint64(p))
+			return
+		}
+		seen[p] = true
+
+		// Entries are combined by sum so that iteration order does not
+		// matter, and each gets its own copy of seen so that a pointer
+		// shared between entries hashes the same in all of them.
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			var entry maphash.Hash
+			entry.SetSeed(h.Seed())
+			entrySeen := maps.Clone(seen)
+			hashValue(&entry, iter.Key(), entrySeen)
+			hashValue(&entry, iter.Value(), entrySeen)
+			sum += entry.Sum64()
+		}
+		hashUint(h, uint64(v.Len()))
+		hashUint(h, sum)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		hashUint(h, uint64(v.Pointer()))
+	}
+}
@@ -0,0 +1,51 @@
+package lfu_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestMutationCheckMapsSharingPointers(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	c := lfu.NewInMemoryCache(1000, time.Hour, 0, lfu.WithMutationCheck(logger))
+	defer c.Shutdown(context.Background())
+
+	type payload struct{ n int }
+	for i := 0; i < 200; i++ {
+		shared := &payload{n: i}
+		value := make(map[string]*payload, 8)
+		for j := 0; j < 8; j++ {
+			value[fmt.Sprint(j)] = shared
+		}
+		key := fmt.Sprint(i)
+		c.Set(key, value, 0)
+		c.Delete(key)
+	}
+
+	if logs.Len() != 0 {
+		t.Fatalf("unmodified values reported as mutated:\n%s", logs.String())
+	}
+}
+
+func TestMutationCheckReportsMutation(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	c := lfu.NewInMemoryCache(10, time.Hour, 0, lfu.WithMutationCheck(logger))
+	defer c.Shutdown(context.Background())
+
+	value := map[string]int{"a": 1}
+	c.Set("k", value, 0)
+	value["a"] = 2
+	c.Delete("k")
+
+	if !bytes.Contains(logs.Bytes(), []byte("modified in place")) {
+		t.Fatal("in-place modification was not reported")
+	}
+}
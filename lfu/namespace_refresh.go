@@ -0,0 +1,170 @@
+package lfu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BulkLoader returns the complete contents of namespace ns, keyed by full
+// cache keys built with Key(ns, ...), and the TTL to store them with; a
+// non-positive TTL selects the default expiration.
+type BulkLoader func(ctx context.Context, ns string) (entries map[string]interface{}, ttl time.Duration, err error)
+
+type RefreshStatus struct {
+	LastAttempt time.Time
+	LastSuccess time.Time
+	LastError   error
+	Entries     int
+}
+
+func namespacePrefix(ns string) string {
+	return Key(ns) + string(keyDelimiter)
+}
+
+// ScheduleNamespaceRefresh loads namespace ns with loader right away and
+// then every interval until the returned stop function is called or the
+// cache is shut down. Each load runs without the cache lock; its result then
+// replaces the namespace in one step, so readers see either the old or the
+// new contents and keys missing from the new load are deleted. A failed
+// load leaves the namespace untouched.
+func (c *Cache[K, V]) ScheduleNamespaceRefresh(ns string, interval time.Duration, loader BulkLoader) (stop func()) {
+	if c.writable() != nil || c.checkCallbacks(loader == nil) != nil || interval <= 0 {
+		return func() {}
+	}
+
+	stopped := make(chan struct{})
+	var once bool
+
+	c.lock(opSet)
+	if c.closed.Load() {
+		c.unlock()
+		return func() {}
+	}
+	if c.refreshStatus == nil {
+		c.refreshStatus = make(map[string]RefreshStatus)
+	}
+	c.refreshStatus[ns] = RefreshStatus{}
+	c.workers.Add(1)
+	c.unlock()
+
+	ticker := c.newTicker(interval)
+	go func() {
+		defer c.workers.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-c.done:
+			case <-stopped:
+			}
+			cancel()
+		}()
+
+		defer ticker.Stop()
+
+		for refreshed := false; ; refreshed = true {
+			c.refreshNamespace(ctx, ns, loader)
+			if refreshed {
+				ticker.Done()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+			}
+		}
+	}()
+
+	return func() {
+		c.lock(opSet)
+		defer c.unlock()
+
+		if !once {
+			once = true
+			close(stopped)
+		}
+	}
+}
+
+func (c *Cache[K, V]) refreshNamespace(ctx context.Context, ns string, loader BulkLoader) {
+	defer c.slowOp("refresh", c.slowStart(), "namespace", ns)
+
+	ctx, cancel := c.loaderContext(ctx)
+	defer cancel()
+
+	attempt := c.clock.Now()
+	entries, ttl, err := loader(ctx, ns)
+	c.countLoaderTimeout(ctx, err)
+
+	prefix := namespacePrefix(ns)
+	typed := make(map[K]V, len(entries))
+	if err == nil {
+		for key, value := range entries {
+			if !strings.HasPrefix(key, prefix) {
+				err = fmt.Errorf("Key %q is outside namespace %q", key, ns)
+				break
+			}
+			k, kok := any(key).(K)
+			v, vok := value.(V)
+			if !kok || !vok {
+				err = fmt.Errorf("Entry %q does not match the cache key or value type", key)
+				break
+			}
+			typed[k] = v
+		}
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	status := c.refreshStatus[ns]
+	status.LastAttempt = attempt
+	status.LastError = err
+	defer func() { c.refreshStatus[ns] = status }()
+
+	if err != nil || c.closed.Load() {
+		return
+	}
+
+	c.drainHits()
+
+	for key, item := range c.items {
+		s, ok := any(key).(string)
+		if _, keep := typed[key]; ok && !keep && strings.HasPrefix(s, prefix) {
+			c.removeItem(item, key, ReasonDelete)
+			c.audit("", auditDelete, key, nil)
+		}
+	}
+	if c.overflow != nil {
+		for key := range c.overflow.entries {
+			s, ok := any(key).(string)
+			if _, keep := typed[key]; ok && !keep && strings.HasPrefix(s, prefix) {
+				c.evictOverflow(key, ReasonDelete)
+				c.audit("", auditDelete, key, nil)
+			}
+		}
+	}
+	for key, value := range typed {
+		c.put("", key, value, ttl)
+	}
+
+	status.LastSuccess = c.clock.Now()
+	status.Entries = len(entries)
+}
+
+func (c *Cache[K, V]) NamespaceRefreshStatus(ns string) (RefreshStatus, bool) {
+	if c.usable() != nil {
+		return RefreshStatus{}, false
+	}
+
+	c.lock(opStats)
+	defer c.unlock()
+
+	status, found := c.refreshStatus[ns]
+
+	return status, found
+}
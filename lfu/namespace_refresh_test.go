@@ -0,0 +1,42 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestNamespaceRefreshDropsOverflowedKeys(t *testing.T) {
+	c, _ := newClockCache(t, 2, 0, lfu.WithOverflow(4))
+
+	a, b := lfu.Key("ns", "a"), lfu.Key("ns", "b")
+	var loads int
+	loader := func(ctx context.Context, ns string) (map[string]interface{}, time.Duration, error) {
+		loads++
+		if loads < 3 {
+			return map[string]interface{}{a: 1, b: 2}, time.Hour, nil
+		}
+		return map[string]interface{}{a: 1}, time.Hour, nil
+	}
+	stop := c.ScheduleNamespaceRefresh("ns", time.Minute, loader)
+	defer stop()
+
+	cachetest.AdvanceTime(c, time.Minute)
+	c.Set("x", 3, time.Hour)
+	for i := 0; i < 5; i++ {
+		c.Get("x")
+	}
+	c.Set("y", 4, time.Hour)
+
+	cachetest.AdvanceTime(c, time.Minute)
+
+	if _, ok := c.Get(b); ok {
+		t.Fatal("key missing from the new load was served from overflow")
+	}
+	if _, ok := c.Get(a); !ok {
+		t.Fatal("key in the new load is missing")
+	}
+}
@@ -0,0 +1,75 @@
+package lfu
+
+import "fmt"
+
+// EvictionReason tells why an entry left the cache.
+type EvictionReason int
+
+const (
+	// ReasonDelete covers explicit removals: Delete, InvalidateSubtree and
+	// keys dropped by a namespace refresh.
+	ReasonDelete EvictionReason = iota
+	ReasonExpired
+	// ReasonCapacity means the entry was evicted to make room.
+	ReasonCapacity
+	// ReasonCold means a sweep purged the entry under WithColdPurge.
+	ReasonCold
+)
+
+var reasonNames = [...]string{
+	ReasonDelete:   "delete",
+	ReasonExpired:  "expired",
+	ReasonCapacity: "capacity",
+	ReasonCold:     "cold",
+}
+
+func (r EvictionReason) String() string {
+	if r >= 0 && int(r) < len(reasonNames) {
+		return reasonNames[r]
+	}
+
+	return fmt.Sprintf("EvictionReason(%d)", int(r))
+}
+
+// WithOnEvict registers fn to be called for every entry that leaves the
+// cache other than by being overwritten. Entries moved to the overflow tier
+// are reported only once they leave it. fn runs on the goroutine that
+// removed the entry, after the cache lock is released, so it may use the
+// cache. Its key and value types must match the cache's; a mismatched fn is
+// ignored, or panics in strict mode.
+func WithOnEvict[K comparable, V any](fn func(key K, value V, reason EvictionReason)) Option {
+	return func(c *settings) {
+		if fn != nil {
+			c.onEvictFunc = fn
+		}
+	}
+}
+
+func (c *Cache[K, V]) evicted(key K, value V, reason EvictionReason) {
+	if fn := c.onEvict; fn != nil {
+		c.afterUnlock(func() { fn(key, value, reason) })
+	}
+
+	if fn := c.onExpire; fn != nil && reason == ReasonExpired {
+		c.afterUnlock(func() { fn(key, value) })
+	}
+}
+
+// evictOverflow removes key from the overflow tier as an eviction.
+func (c *Cache[K, V]) evictOverflow(key K, reason EvictionReason) bool {
+	element, ok := c.overflow.entries[key]
+	if !ok {
+		return false
+	}
+
+	item := c.overflow.item(element)
+	c.verify(item, key, reason)
+	c.dropOverflow(key)
+	if reason != ReasonCapacity {
+		c.countRemoval(reason)
+	}
+	c.evicted(key, item.Value, reason)
+	c.invalidateLinked(key, reason)
+
+	return true
+}
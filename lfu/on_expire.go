@@ -0,0 +1,15 @@
+package lfu
+
+// WithOnExpire registers fn to be called for every entry removed because
+// its TTL ran out, in addition to any WithOnEvict callback. Expired entries
+// are removed by maintenance sweeps, in which case fn runs on the
+// maintenance goroutine, or lazily by the operation that finds them, in
+// which case it runs on the caller's goroutine. Either way it runs after the
+// cache lock is released. The same type rules as WithOnEvict apply.
+func WithOnExpire[K comparable, V any](fn func(key K, value V)) Option {
+	return func(c *settings) {
+		if fn != nil {
+			c.onExpireFunc = fn
+		}
+	}
+}
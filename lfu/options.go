@@ -0,0 +1,94 @@
+package lfu
+
+import (
+	"hash/maphash"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+type Option func(c *settings)
+
+// settings holds what options configure. It is embedded in Cache, so
+// options work for every key and value type.
+type settings struct {
+	size              int
+	defaultExpiration time.Duration
+	cleanupInterval   time.Duration
+	name              string
+	expvarName        string
+	penaltyWindow     time.Duration
+	loaderTimeout     time.Duration
+	highWatermark     float64
+	onHighWatermark   func(cost, capacity int)
+	clock             Clock
+	monotonic         bool
+	typeTTL           map[reflect.Type]time.Duration
+	historyLen        int
+	coldFreq          uint64
+	coldResidency     time.Duration
+	levels            []uint64
+	tieBreak          TieBreak
+	policyFunc        interface{}
+	tinyLFU           bool
+	wTinyLFU          bool
+	decayInterval     time.Duration
+	decayOps          uint64
+	mutationLogger    *slog.Logger
+	mutationSeed      maphash.Seed
+	costFunc          func(value interface{}) int
+	onEvictFunc       interface{}
+	onExpireFunc      interface{}
+	recycler          func(old interface{})
+	equal             func(old, new interface{}) bool
+	paths             *pathNode
+	overflowSize      int
+	filter            *negativeFilter
+	interned          *internTable
+	webhook           *webhook
+	activeSamples     int
+	activeThreshold   float64
+	grace             time.Duration
+	auditLog          *auditLog
+	preSweep          func(SweepReport)
+	postSweep         func(SweepReport)
+	readMostly        bool
+	leaseTimeout      time.Duration
+	slowThreshold     time.Duration
+	slowLogger        *slog.Logger
+	strict            bool
+}
+
+// noExpiry is the default TTL of New: entries never expire in practice, yet
+// adding it to a clock reading cannot overflow.
+const noExpiry = 100 * 365 * 24 * time.Hour
+
+// New creates a cache configured entirely by options. Without WithCapacity
+// the cache has no capacity and rejects writes. Without WithDefaultTTL,
+// entries stored with a zero duration do not expire.
+func New(opts ...Option) *InMemoryCache {
+	return NewInMemoryCache(0, noExpiry, 0, opts...)
+}
+
+// WithCapacity sets the cache size, overriding the constructor argument.
+func WithCapacity(n int) Option {
+	return func(c *settings) {
+		c.size = n
+	}
+}
+
+// WithDefaultTTL sets the default expiration, overriding the constructor
+// argument.
+func WithDefaultTTL(d time.Duration) Option {
+	return func(c *settings) {
+		c.defaultExpiration = d
+	}
+}
+
+// WithCleanupInterval sets the interval of the maintenance sweep,
+// overriding the constructor argument.
+func WithCleanupInterval(d time.Duration) Option {
+	return func(c *settings) {
+		c.cleanupInterval = d
+	}
+}
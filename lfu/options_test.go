@@ -0,0 +1,30 @@
+package lfu_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestNewWithoutOptions(t *testing.T) {
+	c := lfu.New()
+	defer c.Shutdown(context.Background())
+
+	if err := c.Set("k", 1, 0); !errors.Is(err, lfu.ErrZeroCapacity) {
+		t.Fatalf("Set = %v, want ErrZeroCapacity", err)
+	}
+}
+
+func TestNewWithoutDefaultTTL(t *testing.T) {
+	c := lfu.New(lfu.WithCapacity(4))
+	defer c.Shutdown(context.Background())
+
+	if err := c.Set("k", 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := c.Get("k"); !ok || v != 1 {
+		t.Fatalf("Get = %v, %v, want 1, true", v, ok)
+	}
+}
@@ -0,0 +1,108 @@
+package lfu
+
+import "container/list"
+
+type overflowCache[K comparable, V any] struct {
+	list    *list.List
+	entries map[K]*list.Element
+	size    int
+}
+
+type overflowEntry[K comparable, V any] struct {
+	key  K
+	item *Item[K, V]
+}
+
+// WithOverflow keeps up to n entries evicted for capacity in a secondary
+// LRU tier instead of dropping them. A Get that misses the main cache but
+// finds the key there moves it back into the main cache with its frequency
+// intact. Overflow entries do not count toward the cache size.
+func WithOverflow(n int) Option {
+	return func(c *settings) {
+		if n > 0 {
+			c.overflowSize = n
+		}
+	}
+}
+
+func newOverflowCache[K comparable, V any](n int) *overflowCache[K, V] {
+	return &overflowCache[K, V]{
+		list:    list.New(),
+		entries: make(map[K]*list.Element, n),
+		size:    n,
+	}
+}
+
+func (o *overflowCache[K, V]) item(element *list.Element) *Item[K, V] {
+	return element.Value.(overflowEntry[K, V]).item
+}
+
+func (c *Cache[K, V]) pushOverflow(item *Item[K, V], key K) {
+	if c.overflow == nil {
+		return
+	}
+
+	c.dropOverflow(key)
+	c.filterAdd(key)
+	c.overflow.entries[key] = c.overflow.list.PushFront(overflowEntry[K, V]{key: key, item: item})
+
+	if c.overflow.list.Len() > c.overflow.size {
+		oldest := c.overflow.list.Back()
+		c.evictOverflow(oldest.Value.(overflowEntry[K, V]).key, ReasonCapacity)
+	}
+}
+
+func (c *Cache[K, V]) dropOverflow(key K) bool {
+	if c.overflow == nil {
+		return false
+	}
+
+	element, ok := c.overflow.entries[key]
+	if !ok {
+		return false
+	}
+
+	c.overflow.list.Remove(element)
+	delete(c.overflow.entries, key)
+	c.filterRemove(key)
+
+	return true
+}
+
+func (c *Cache[K, V]) promoteOverflow(key K) (V, bool) {
+	var zero V
+	if c.overflow == nil {
+		return zero, false
+	}
+
+	element, ok := c.overflow.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	item := c.overflow.item(element)
+	if c.isExpired(item) {
+		c.evictOverflow(key, ReasonExpired)
+		return zero, false
+	}
+	c.dropOverflow(key)
+
+	c.insertItem(item, key)
+
+	return item.Value, true
+}
+
+func (c *Cache[K, V]) removeExpiredOverflow() (removed int) {
+	if c.overflow == nil {
+		return 0
+	}
+
+	for key, element := range c.overflow.entries {
+		if c.isReapable(c.overflow.item(element)) {
+			c.evictOverflow(key, ReasonExpired)
+			removed++
+		}
+	}
+
+	return removed
+}
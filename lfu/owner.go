@@ -0,0 +1,60 @@
+package lfu
+
+import "time"
+
+// SetOwned is Set that labels the entry with owner, typically a tenant ID.
+// Every other write that replaces the value, such as Set, Replace,
+// SetMany or Increment, clears the label.
+func (c *Cache[K, V]) SetOwned(key K, value V, duration time.Duration, owner string) error {
+	return c.set("", key, value, duration, nil, owner)
+}
+
+// GetOwned is Get that only finds entries labelled with owner by
+// SetOwned. An entry with another owner is reported as a miss and its
+// frequency is left alone, so a key collision between tenants cannot leak
+// one tenant's value to the other.
+func (c *Cache[K, V]) GetOwned(key K, owner string) (V, bool) {
+	return c.getOwned(key, owner)
+}
+
+func (c *Cache[K, V]) getOwned(key K, owner string) (value V, found bool) {
+	defer c.slowKeyOp("get", key, c.slowStart())
+
+	if c.usable() != nil {
+		return value, false
+	}
+	defer func() { c.countLookup(found) }()
+
+	c.lock(opGet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	item, found := c.items[key]
+	if !found {
+		if c.overflow == nil {
+			return value, false
+		}
+		element, ok := c.overflow.entries[key]
+		if !ok || c.overflow.item(element).owner != owner {
+			return value, false
+		}
+		return c.promoteOverflow(key)
+	}
+	if item.owner != owner {
+		return value, false
+	}
+
+	if c.isExpired(item) {
+		if c.inGrace(item.Expiration, item.deadline) {
+			value, _, found = c.graceRead(item.Value)
+			return value, found
+		}
+		c.removeItem(item, key, ReasonExpired)
+		return value, false
+	}
+
+	c.upgradeItem(item, key)
+
+	return item.Value, true
+}
@@ -0,0 +1,78 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestGetOwned(t *testing.T) {
+	c, _ := newClockCache(t, 4, 0)
+
+	c.SetOwned("k", 1, time.Minute, "tenant-a")
+	if _, ok := c.GetOwned("k", "tenant-b"); ok {
+		t.Fatal("GetOwned returned another owner's value")
+	}
+	if v, ok := c.GetOwned("k", "tenant-a"); !ok || v != 1 {
+		t.Fatalf("GetOwned = %v, %v, want 1, true", v, ok)
+	}
+
+	c.Set("k", 2, time.Minute)
+	if _, ok := c.GetOwned("k", "tenant-a"); ok {
+		t.Fatal("Set kept the previous owner")
+	}
+}
+
+func TestGetOwnedOnNilCache(t *testing.T) {
+	var c *lfu.InMemoryCache
+
+	if _, ok := c.GetOwned("k", "tenant-a"); ok {
+		t.Fatal("nil cache reported a hit")
+	}
+}
+
+func TestOverwriteClearsOwner(t *testing.T) {
+	tests := []struct {
+		name      string
+		overwrite func(c *lfu.InMemoryCache, clock *cachetest.Clock)
+	}{
+		{"Set", func(c *lfu.InMemoryCache, _ *cachetest.Clock) { c.Set("k", 2, time.Minute) }},
+		{"Replace", func(c *lfu.InMemoryCache, _ *cachetest.Clock) { c.Replace("k", 2, time.Minute) }},
+		{"SetMany", func(c *lfu.InMemoryCache, _ *cachetest.Clock) {
+			c.SetMany([]lfu.Entry[string, interface{}]{{Key: "k", Value: 2}})
+		}},
+		{"MSet", func(c *lfu.InMemoryCache, _ *cachetest.Clock) {
+			c.MSet(map[string]lfu.ValueWithTTL[interface{}]{"k": {Value: 2}})
+		}},
+		{"GetOrSet", func(c *lfu.InMemoryCache, clock *cachetest.Clock) {
+			clock.Advance(2 * time.Minute)
+			c.GetOrSet("k", 2, time.Minute)
+		}},
+		{"Increment", func(c *lfu.InMemoryCache, _ *cachetest.Clock) { c.Increment("k", 1) }},
+		{"SetField", func(c *lfu.InMemoryCache, clock *cachetest.Clock) {
+			clock.Advance(2 * time.Minute)
+			c.SetField("k", "f", 2, time.Minute)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := cachetest.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			c := lfu.NewInMemoryCache(4, time.Hour, 0, lfu.WithClock(clock))
+			t.Cleanup(func() { c.Shutdown(context.Background()) })
+
+			c.SetOwned("k", 1, time.Minute, "tenant-a")
+			tt.overwrite(c, clock)
+
+			if v, ok := c.GetOwned("k", "tenant-a"); ok {
+				t.Fatalf("GetOwned after %s = %v, want a miss", tt.name, v)
+			}
+			if _, ok := c.Get("k"); !ok {
+				t.Fatalf("%s did not store the value", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package lfu
+
+import "strings"
+
+const pathSeparator = "/"
+
+type pathNode struct {
+	children map[string]*pathNode
+	key      string
+	present  bool
+}
+
+// WithPathIndex maintains a trie of slash-separated key segments so that
+// InvalidateSubtree only visits the affected keys instead of scanning the
+// whole cache.
+func WithPathIndex() Option {
+	return func(c *settings) {
+		c.paths = &pathNode{}
+	}
+}
+
+func (c *Cache[K, V]) indexPath(key K) {
+	if c.paths == nil {
+		return
+	}
+	s, ok := any(key).(string)
+	if !ok {
+		return
+	}
+
+	node := c.paths
+	for _, segment := range strings.Split(s, pathSeparator) {
+		child, ok := node.children[segment]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[string]*pathNode)
+			}
+			child = &pathNode{}
+			node.children[segment] = child
+		}
+		node = child
+	}
+
+	node.key = s
+	node.present = true
+}
+
+func (c *Cache[K, V]) unindexPath(key K) {
+	if c.paths == nil {
+		return
+	}
+	if s, ok := any(key).(string); ok {
+		c.paths.remove(strings.Split(s, pathSeparator))
+	}
+}
+
+func (n *pathNode) remove(segments []string) (empty bool) {
+	if len(segments) == 0 {
+		n.present = false
+		n.key = ""
+		return len(n.children) == 0
+	}
+
+	child, ok := n.children[segments[0]]
+	if ok && child.remove(segments[1:]) {
+		delete(n.children, segments[0])
+	}
+
+	return !n.present && len(n.children) == 0
+}
+
+func (n *pathNode) collect(keys []string) []string {
+	if n.present {
+		keys = append(keys, n.key)
+	}
+	for _, child := range n.children {
+		keys = child.collect(keys)
+	}
+
+	return keys
+}
+
+// InvalidateSubtree deletes the key named by prefix and every key below it
+// in the slash-separated hierarchy; a trailing slash on prefix is ignored,
+// so "users/42/" removes "users/42" and "users/42/profile" but not
+// "users/420". It returns the number of deleted keys, which is always zero
+// for caches whose keys are not strings.
+func (c *Cache[K, V]) InvalidateSubtree(prefix string) int {
+	if c.writable() != nil {
+		return 0
+	}
+
+	c.lock(opDelete)
+	defer c.unlock()
+
+	c.drainHits()
+
+	prefix = strings.TrimSuffix(prefix, pathSeparator)
+
+	var keys []K
+	if c.paths != nil {
+		node := c.paths
+		for _, segment := range strings.Split(prefix, pathSeparator) {
+			if node = node.children[segment]; node == nil {
+				return 0
+			}
+		}
+		for _, key := range node.collect(nil) {
+			keys = append(keys, any(key).(K))
+		}
+	} else {
+		for key := range c.items {
+			if inSubtree(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	for _, key := range keys {
+		c.removeItem(c.items[key], key, ReasonDelete)
+		c.audit("", auditDelete, key, nil)
+	}
+
+	if c.overflow != nil {
+		for key := range c.overflow.entries {
+			if inSubtree(key, prefix) {
+				c.evictOverflow(key, ReasonDelete)
+				c.audit("", auditDelete, key, nil)
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return len(keys)
+}
+
+func inSubtree[K comparable](key K, prefix string) bool {
+	s, ok := any(key).(string)
+
+	return ok && (s == prefix || strings.HasPrefix(s, prefix+pathSeparator))
+}
@@ -0,0 +1,133 @@
+package lfu
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultPenaltyWindow = time.Minute
+
+// LoadPenalty counts loads and the time spent in them.
+type LoadPenalty struct {
+	Loads uint64
+	Time  time.Duration
+}
+
+func (p LoadPenalty) add(other LoadPenalty) LoadPenalty {
+	return LoadPenalty{Loads: p.Loads + other.Loads, Time: p.Time + other.Time}
+}
+
+// MissPenalty is the time spent loading missed keys through GetOrLoad and
+// Coalescer, since creation and over the last complete window. Keys built
+// with Key are attributed to their first part in Namespaces; other keys to
+// the empty namespace.
+type MissPenalty struct {
+	Total      LoadPenalty
+	Window     time.Duration
+	LastWindow LoadPenalty
+	Namespaces map[string]NamespacePenalty
+}
+
+type NamespacePenalty struct {
+	Total      LoadPenalty
+	LastWindow LoadPenalty
+}
+
+// WithMissPenaltyWindow sets the window MissPenalty.LastWindow covers. It
+// defaults to one minute.
+func WithMissPenaltyWindow(d time.Duration) Option {
+	return func(c *settings) {
+		if d > 0 {
+			c.penaltyWindow = d
+		}
+	}
+}
+
+type penaltyTracker struct {
+	mu      sync.Mutex
+	index   int64
+	total   map[string]LoadPenalty
+	current map[string]LoadPenalty
+	last    map[string]LoadPenalty
+}
+
+// roll moves to the window holding now, keeping the one before it only if
+// it has just ended.
+func (t *penaltyTracker) roll(now time.Time, window time.Duration) {
+	index := now.UnixNano() / int64(window)
+	if index == t.index {
+		return
+	}
+
+	if index == t.index+1 {
+		t.last = t.current
+	} else {
+		t.last = nil
+	}
+	t.current = nil
+	t.index = index
+}
+
+func (c *Cache[K, V]) recordPenalty(key K, loads uint64, d time.Duration) {
+	ns := ""
+	if s, ok := any(key).(string); ok {
+		if parts, err := ParseKey(s); err == nil && len(parts) > 1 {
+			ns = parts[0]
+		}
+	}
+	p := LoadPenalty{Loads: loads, Time: d}
+
+	t := &c.penalties
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.roll(c.clock.Now(), c.penaltyWindow)
+	if t.total == nil {
+		t.total = make(map[string]LoadPenalty)
+	}
+	if t.current == nil {
+		t.current = make(map[string]LoadPenalty)
+	}
+	t.total[ns] = t.total[ns].add(p)
+	t.current[ns] = t.current[ns].add(p)
+}
+
+func (c *Cache[K, V]) missPenalty() MissPenalty {
+	t := &c.penalties
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.roll(c.clock.Now(), c.penaltyWindow)
+
+	penalty := MissPenalty{
+		Window:     c.penaltyWindow,
+		Namespaces: make(map[string]NamespacePenalty, len(t.total)),
+	}
+	for ns, p := range t.total {
+		penalty.Total = penalty.Total.add(p)
+		penalty.Namespaces[ns] = NamespacePenalty{Total: p, LastWindow: t.last[ns]}
+	}
+	for _, p := range t.last {
+		penalty.LastWindow = penalty.LastWindow.add(p)
+	}
+
+	return penalty
+}
+
+func (p *MissPenalty) add(other MissPenalty) {
+	p.Total = p.Total.add(other.Total)
+	p.LastWindow = p.LastWindow.add(other.LastWindow)
+	if p.Window == 0 {
+		p.Window = other.Window
+	}
+
+	if p.Namespaces == nil {
+		p.Namespaces = make(map[string]NamespacePenalty, len(other.Namespaces))
+	}
+	for ns, np := range other.Namespaces {
+		sum := p.Namespaces[ns]
+		sum.Total = sum.Total.add(np.Total)
+		sum.LastWindow = sum.LastWindow.add(np.LastWindow)
+		p.Namespaces[ns] = sum
+	}
+}
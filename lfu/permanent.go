@@ -0,0 +1,50 @@
+package lfu
+
+// SetPermanent stores value in a region kept apart from the cached data: it
+// never expires, is never evicted, does not count toward the cache size and
+// has no frequency. Keys there are independent of cached keys.
+func (c *Cache[K, V]) SetPermanent(key K, value V) {
+	if c.writable() != nil {
+		return
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	if c.permanent == nil {
+		c.permanent = make(map[K]V)
+	}
+	c.permanent[key] = value
+	c.audit("", auditSet, key, &value)
+}
+
+func (c *Cache[K, V]) GetPermanent(key K) (V, bool) {
+	if c.usable() != nil {
+		var zero V
+		return zero, false
+	}
+
+	c.lock(opGet)
+	defer c.unlock()
+
+	value, found := c.permanent[key]
+
+	return value, found
+}
+
+func (c *Cache[K, V]) DeletePermanent(key K) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	c.lock(opDelete)
+	defer c.unlock()
+
+	if _, found := c.permanent[key]; !found {
+		return ErrKeyNotFound
+	}
+	delete(c.permanent, key)
+	c.audit("", auditDelete, key, nil)
+
+	return nil
+}
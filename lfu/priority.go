@@ -0,0 +1,22 @@
+package lfu
+
+import "time"
+
+// SetWithPriority is Set that also sets the entry's eviction priority.
+// Among entries of equal frequency, those of lower priority are evicted
+// first; ties are broken as WithTieBreak selects. Entries start at
+// priority 0 and Set leaves an existing entry's priority unchanged.
+// Priorities have no effect under WithEvictionPolicy.
+func (c *Cache[K, V]) SetWithPriority(key K, value V, duration time.Duration, priority int) error {
+	return c.set("", key, value, duration, &priority, "")
+}
+
+func (c *Cache[K, V]) prioritize(item *Item[K, V], priority int) {
+	switch {
+	case item.priority == 0 && priority != 0:
+		c.prioritized++
+	case item.priority != 0 && priority == 0:
+		c.prioritized--
+	}
+	item.priority = priority
+}
@@ -0,0 +1,42 @@
+package lfu
+
+// Range calls fn for every live entry present when Range starts, until fn
+// returns false. Keys are snapshotted up front and each value is read just
+// before its call, so fn runs without the cache lock and may Set or Delete
+// any key: entries removed meanwhile are skipped and entries added are not
+// visited. Range does not affect frequencies.
+func (c *Cache[K, V]) Range(fn func(key K, value V) bool) {
+	defer c.slowOp("range", c.slowStart())
+
+	if c.usable() != nil || c.checkCallbacks(fn == nil) != nil {
+		return
+	}
+
+	c.lock(opGet)
+	c.drainHits()
+	keys := make([]K, 0, len(c.items))
+	for key := range c.items {
+		keys = append(keys, key)
+	}
+	c.unlock()
+
+	for _, key := range keys {
+		value, found := c.peek(key)
+		if found && !fn(key, value) {
+			return
+		}
+	}
+}
+
+func (c *Cache[K, V]) peek(key K) (V, bool) {
+	c.lock(opGet)
+	defer c.unlock()
+
+	item, found := c.items[key]
+	if !found || c.isExpired(item) {
+		var zero V
+		return zero, false
+	}
+
+	return item.Value, true
+}
@@ -0,0 +1,91 @@
+package lfu
+
+import "time"
+
+const readMostlyBuffer = 1024
+
+type readEntry[V any] struct {
+	value      V
+	expiration time.Time
+	deadline   time.Duration
+}
+
+// WithReadMostly serves Get hits from a sync.Map without taking the cache
+// lock. Frequency updates for those hits are buffered and applied in batches
+// by the next operation that holds the lock, so eviction order may lag
+// slightly behind the actual access pattern.
+func WithReadMostly() Option {
+	return func(c *settings) {
+		c.readMostly = true
+	}
+}
+
+func (c *Cache[K, V]) getReadMostly(key K) (V, bool, bool) {
+	v, found := c.index.Load(key)
+	if !found {
+		if c.overflow != nil {
+			return c.getLocked(key)
+		}
+		var zero V
+		return zero, false, false
+	}
+
+	entry := v.(*readEntry[V])
+	if c.pastDeadline(entry.expiration, entry.deadline) {
+		if c.inGrace(entry.expiration, entry.deadline) {
+			return c.graceRead(entry.value)
+		}
+		return c.getLocked(key)
+	}
+
+	select {
+	case c.hits <- key:
+	default:
+		c.lock(opGet)
+		c.drainHits()
+		if item, ok := c.items[key]; ok {
+			c.upgradeItem(item, key)
+		}
+		c.unlock()
+	}
+
+	return entry.value, false, true
+}
+
+func (c *Cache[K, V]) drainHits() {
+	c.drainSharedHits()
+	if c.hits == nil {
+		return
+	}
+
+	for {
+		select {
+		case key := <-c.hits:
+			if item, ok := c.items[key]; ok && !c.isExpired(item) {
+				c.upgradeItem(item, key)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (c *Cache[K, V]) publish(key K, item *Item[K, V]) {
+	if !c.readMostly {
+		return
+	}
+
+	c.index.Store(key, &readEntry[V]{
+		value:      item.Value,
+		expiration: item.Expiration,
+		deadline:   item.deadline,
+	})
+}
+
+func (c *Cache[K, V]) unpublish(key K) {
+	if !c.readMostly {
+		return
+	}
+
+	c.index.Delete(key)
+}
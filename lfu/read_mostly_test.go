@@ -0,0 +1,47 @@
+package lfu_test
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+// BenchmarkParallelGet compares the default cache with WithReadMostly under
+// concurrent Get hits; run it with -cpu to vary the parallelism.
+func BenchmarkParallelGet(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []lfu.Option
+	}{
+		{"Default", nil},
+		{"ReadMostly", []lfu.Option{lfu.WithReadMostly()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			const size = 1024
+
+			c := lfu.NewInMemoryCache(size, time.Hour, 0, bc.opts...)
+			defer c.Shutdown(context.Background())
+
+			keys := make([]string, size)
+			for i := range keys {
+				keys[i] = "key-" + strconv.Itoa(i)
+				c.Set(keys[i], i, 0)
+			}
+
+			var worker atomic.Int64
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := int(worker.Add(1)) * 7919
+				for pb.Next() {
+					c.Get(keys[i%size])
+					i++
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package lfu
+
+import "reflect"
+
+// WithValueRecycler registers fn to receive values that a Set has replaced
+// and that are no longer retained by the cache, e.g. to return buffers to a
+// sync.Pool. With WithHistory a value is handed over only once it falls out
+// of the history. fn runs with the cache lock held and must not call back
+// into the cache; callers must not keep using values obtained from Get
+// once they may have been replaced.
+func WithValueRecycler(fn func(old interface{})) Option {
+	return func(c *settings) {
+		c.recycler = fn
+	}
+}
+
+// sharesMemory reports whether a and b are the same pointer, map, channel
+// or function, or slices over overlapping parts of one array.
+func sharesMemory(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return false
+	}
+
+	switch va.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return !va.IsNil() && va.Pointer() == vb.Pointer()
+	case reflect.Slice:
+		if va.Cap() == 0 || vb.Cap() == 0 {
+			return false
+		}
+		size := va.Type().Elem().Size()
+		aStart, bStart := va.Pointer(), vb.Pointer()
+		aEnd := aStart + uintptr(va.Cap())*size
+		bEnd := bStart + uintptr(vb.Cap())*size
+		return aStart < bEnd && bStart < aEnd
+	}
+
+	return false
+}
+
+func (c *Cache[K, V]) recycle(old V) {
+	if c.recycler != nil {
+		c.recycler(any(old))
+	}
+}
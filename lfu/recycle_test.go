@@ -0,0 +1,36 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestRecyclerSkipsValueStillCached(t *testing.T) {
+	var recycled []interface{}
+	c := lfu.NewInMemoryCache(4, time.Hour, 0, lfu.WithValueRecycler(func(old interface{}) {
+		recycled = append(recycled, old)
+	}))
+	defer c.Shutdown(context.Background())
+
+	buf := make([]byte, 4, 8)
+	c.Set("b", buf, 0)
+	c.Set("b", buf[:2], 0)
+	c.Set("b", buf, 0)
+
+	type payload struct{ n int }
+	p := &payload{n: 1}
+	c.Set("p", p, 0)
+	c.Set("p", p, 0)
+
+	if len(recycled) != 0 {
+		t.Fatalf("recycled %d values that are still cached", len(recycled))
+	}
+
+	c.Set("b", []byte("new"), 0)
+	if len(recycled) != 1 {
+		t.Fatalf("recycled %d values after a real replacement, want 1", len(recycled))
+	}
+}
@@ -0,0 +1,95 @@
+package lfu
+
+import "sync"
+
+type statsSource interface {
+	Stats() Stats
+}
+
+var registry = struct {
+	sync.Mutex
+	caches map[statsSource]struct{}
+}{caches: make(map[statsSource]struct{})}
+
+// WithName names the cache and registers it for AggregateStats until it is
+// shut down. Names need not be unique. The registry holds the cache
+// strongly, so a named cache must be closed with Shutdown or Close to be
+// unregistered; one that is merely dropped stays counted in AggregateStats
+// and is never garbage collected.
+func WithName(name string) Option {
+	return func(c *settings) {
+		c.name = name
+	}
+}
+
+func (c *Cache[K, V]) Name() string {
+	if c == nil {
+		return ""
+	}
+
+	return c.name
+}
+
+func register(c statsSource) {
+	registry.Lock()
+	registry.caches[c] = struct{}{}
+	registry.Unlock()
+}
+
+func unregister(c statsSource) {
+	registry.Lock()
+	delete(registry.caches, c)
+	registry.Unlock()
+}
+
+// AggregateStats sums the Stats of every named cache that has not been shut
+// down. Lock statistics are summed per operation.
+func AggregateStats() Stats {
+	registry.Lock()
+	caches := make([]statsSource, 0, len(registry.caches))
+	for c := range registry.caches {
+		caches = append(caches, c)
+	}
+	registry.Unlock()
+
+	total := Stats{Lock: make(map[string]LockStats, lockOpCount)}
+	for _, c := range caches {
+		total.add(c.Stats())
+	}
+
+	return total
+}
+
+func (s *Stats) add(other Stats) {
+	s.Len += other.Len
+	s.Cost += other.Cost
+	s.Capacity += other.Capacity
+	s.Overflow += other.Overflow
+	s.Hits += other.Hits
+	s.Misses += other.Misses
+	s.Sets += other.Sets
+	s.Deletes += other.Deletes
+	s.Evictions += other.Evictions
+	s.Expirations += other.Expirations
+	s.GraceReads += other.GraceReads
+	s.LoaderTimeouts += other.LoaderTimeouts
+	s.MissPenalty.add(other.MissPenalty)
+
+	for i, n := range other.Levels {
+		if i == len(s.Levels) {
+			s.Levels = append(s.Levels, 0)
+		}
+		s.Levels[i] += n
+	}
+
+	for op, lock := range other.Lock {
+		sum := s.Lock[op]
+		sum.Acquisitions += lock.Acquisitions
+		sum.Contended += lock.Contended
+		sum.WaitTotal += lock.WaitTotal
+		for i, n := range lock.WaitHistogram {
+			sum.WaitHistogram[i] += n
+		}
+		s.Lock[op] = sum
+	}
+}
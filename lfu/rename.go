@@ -0,0 +1,71 @@
+package lfu
+
+// Rename moves the entry under oldKey to newKey with its value,
+// expiration and frequency. It returns ErrKeyNotFound when oldKey is
+// absent or expired, and ErrKeyExists when newKey holds a live entry and
+// overwrite is false; otherwise that entry is deleted first. Keys linked
+// to oldKey are invalidated as if it had been deleted, and subscribers and
+// webhooks receive an EventDelete for oldKey and an EventSet for newKey.
+func (c *Cache[K, V]) Rename(oldKey, newKey K, overwrite bool) error {
+	defer c.slowKeyOp("rename", oldKey, c.slowStart())
+
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	item, found := c.items[oldKey]
+	if !found {
+		if _, ok := c.promoteOverflow(oldKey); !ok {
+			return ErrKeyNotFound
+		}
+		item = c.items[oldKey]
+	}
+	if c.isExpired(item) {
+		return ErrKeyNotFound
+	}
+	if oldKey == newKey {
+		return nil
+	}
+
+	if dest, found := c.items[newKey]; found {
+		if !overwrite && !c.isExpired(dest) {
+			return ErrKeyExists
+		}
+		c.removeItem(dest, newKey, ReasonDelete)
+	} else if c.overflow != nil {
+		if element, ok := c.overflow.entries[newKey]; ok {
+			if !overwrite && !c.isExpired(c.overflow.item(element)) {
+				return ErrKeyExists
+			}
+			c.evictOverflow(newKey, ReasonDelete)
+		}
+	}
+
+	c.invalidateLinked(oldKey, ReasonDelete)
+	c.audit("", auditDelete, oldKey, nil)
+	delete(c.items, oldKey)
+	c.filterRemove(oldKey)
+	c.unpublish(oldKey)
+	c.unindexPath(oldKey)
+	if c.policy != nil {
+		c.policy.OnRemove(oldKey)
+	}
+
+	item.key = newKey
+	c.audit("", auditSet, newKey, &item.Value)
+	c.items[newKey] = item
+	c.filterAdd(newKey)
+	c.indexPath(newKey)
+	c.publish(newKey, item)
+	if c.policy != nil {
+		c.policy.OnAdd(newKey)
+	}
+	c.emitRename(item, oldKey, newKey)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestRenameNotifiesAndAudits(t *testing.T) {
+	c, _ := newClockCache(t, 4, 0, lfu.WithAudit(lfu.AuditConfig{Size: 16}))
+
+	var events []lfu.WebhookEvent
+	unsubscribe := c.Subscribe(func(string) bool { return true }, func(ev lfu.WebhookEvent) {
+		events = append(events, ev)
+	})
+	defer unsubscribe()
+
+	c.Set("old", 1, time.Hour)
+	if err := c.Rename("old", "new", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 ||
+		events[0].Type != lfu.EventDelete || events[0].Key != "old" ||
+		events[1].Type != lfu.EventSet || events[1].Key != "new" {
+		t.Fatalf("events = %+v, want a delete of old and a set of new", events)
+	}
+
+	if trail := c.AuditTrail("old"); len(trail) == 0 || trail[len(trail)-1].Op != "delete" {
+		t.Fatalf("audit trail for old = %+v, want a trailing delete", trail)
+	}
+	if trail := c.AuditTrail("new"); len(trail) != 1 || trail[0].Op != "set" {
+		t.Fatalf("audit trail for new = %+v, want one set", trail)
+	}
+}
+
+func TestRenameOverOverflowedKeyInvalidatesLinks(t *testing.T) {
+	c, _ := newClockCache(t, 2, 0, lfu.WithOverflow(4))
+	derived := lfu.NewInMemoryCache(4, time.Hour, 0)
+	t.Cleanup(func() { derived.Shutdown(context.Background()) })
+	c.LinkInvalidation(derived, func(key string) []string { return []string{"derived:" + key} })
+
+	c.Set("dest", 1, time.Hour)
+	c.Set("src", 2, time.Hour)
+	for i := 0; i < 3; i++ {
+		c.Get("src")
+	}
+	c.Set("filler", 3, time.Hour)
+	derived.Set("derived:dest", 1, time.Hour)
+
+	if err := c.Rename("src", "dest", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := derived.Get("derived:dest"); ok {
+		t.Fatal("overwriting an overflowed key left its derived key in the linked cache")
+	}
+	if v, ok := c.Get("dest"); !ok || v != 2 {
+		t.Fatalf("Get(dest) = %v, %v, want 2, true", v, ok)
+	}
+}
@@ -0,0 +1,37 @@
+package lfu
+
+import "time"
+
+// Replace is Set that only overwrites a live entry, keeping its frequency,
+// and otherwise returns ErrKeyNotFound, so a refresh cannot bring back a
+// key that was deleted, evicted or has expired.
+func (c *Cache[K, V]) Replace(key K, value V, duration time.Duration) error {
+	defer c.slowKeyOp("set", key, c.slowStart())
+
+	if err := c.writable(); err != nil {
+		return err
+	}
+	c.checkTTL(duration)
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	item, found := c.items[key]
+	if !found {
+		if _, ok := c.promoteOverflow(key); !ok {
+			return ErrKeyNotFound
+		}
+		item = c.items[key]
+	}
+	if c.isExpired(item) {
+		return ErrKeyNotFound
+	}
+
+	if c.put("", key, value, duration) == SetTooLarge {
+		return ErrItemTooLarge
+	}
+
+	return nil
+}
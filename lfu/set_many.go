@@ -0,0 +1,58 @@
+package lfu
+
+import "time"
+
+// SetResult is the outcome of one write in SetMany.
+type SetResult int
+
+const (
+	// SetRejected means the write was dropped because the cache is nil,
+	// closed or has no capacity.
+	SetRejected SetResult = iota
+	SetStored
+	SetReplaced
+	// SetUnchanged means write suppression kept the existing equal value.
+	SetUnchanged
+	// SetTooLarge means the value alone costs more than the cache size.
+	SetTooLarge
+	// SetNotAdmitted means WithTinyLFU kept the new key out of a full
+	// cache.
+	SetNotAdmitted
+)
+
+// Entry is one write for SetMany. A non-positive TTL selects the default
+// expiration.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+	TTL   time.Duration
+}
+
+// SetMany writes entries in order under a single lock acquisition and
+// returns the outcome of each, aligned with entries.
+func (c *Cache[K, V]) SetMany(entries []Entry[K, V]) []SetResult {
+	defer c.slowOp("set_many", c.slowStart())
+
+	results := make([]SetResult, len(entries))
+	if c.writable() != nil {
+		return results
+	}
+	if c.size <= 0 {
+		c.misuse(ErrZeroCapacity)
+		return results
+	}
+	for _, entry := range entries {
+		c.checkTTL(entry.TTL)
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.drainHits()
+
+	for i, entry := range entries {
+		results[i] = c.put("", entry.Key, entry.Value, entry.TTL)
+	}
+
+	return results
+}
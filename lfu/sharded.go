@@ -0,0 +1,193 @@
+package lfu
+
+import (
+	"context"
+	"errors"
+	"hash/maphash"
+	"time"
+)
+
+// Sharded spreads keys over independent caches by key hash, so operations
+// on different shards never contend for the same lock. Capacity is split
+// evenly between the shards and each one evicts on its own: the entry
+// evicted is the least frequently used of its shard, not of the whole
+// cache.
+type Sharded[K comparable, V any] struct {
+	shards []*Cache[K, V]
+	mask   uint64
+	seed   maphash.Seed
+	expvar string
+}
+
+type ShardedInMemoryCache = Sharded[string, interface{}]
+
+func NewShardedInMemoryCache(shards, size int, defaultExpiration, cleanupInterval time.Duration, opts ...Option) *ShardedInMemoryCache {
+	return NewSharded[string, interface{}](shards, size, defaultExpiration, cleanupInterval, opts...)
+}
+
+// minShardSize is the smallest capacity NewSharded gives a shard. Smaller
+// shards would evict far from the least frequently used entry overall.
+const minShardSize = 64
+
+// NewSharded builds a cache of shards shards, rounded up to a power of two
+// and then halved until every shard holds at least 64 entries, each created
+// by NewCache with opts and its share of size, or of the WithCapacity
+// option when opts has one. Every shard registers under WithName on its
+// own, so AggregateStats counts the whole cache; WithExpvar publishes the
+// combined Stats.
+func NewSharded[K comparable, V any](shards, size int, defaultExpiration, cleanupInterval time.Duration, opts ...Option) *Sharded[K, V] {
+	config := settings{size: size}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	size = config.size
+
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	for n > 1 && size/n < minShardSize {
+		n >>= 1
+	}
+
+	s := &Sharded[K, V]{
+		shards: make([]*Cache[K, V], n),
+		mask:   uint64(n - 1),
+		seed:   maphash.MakeSeed(),
+		expvar: config.expvarName,
+	}
+	for i := range s.shards {
+		share := size / n
+		if i < size%n {
+			share++
+		}
+		shardOpts := append(opts[:len(opts):len(opts)], func(c *settings) {
+			c.size = share
+			c.expvarName = ""
+		})
+		s.shards[i] = NewCache[K, V](share, defaultExpiration, cleanupInterval, shardOpts...)
+	}
+
+	if s.expvar != "" {
+		publishExpvar(s.expvar, s)
+	}
+
+	return s
+}
+
+func (s *Sharded[K, V]) shard(key K) *Cache[K, V] {
+	return s.shards[hashKey(s.seed, key)&s.mask]
+}
+
+func (s *Sharded[K, V]) Set(key K, value V, duration time.Duration) error {
+	if s == nil {
+		return ErrNilCache
+	}
+
+	return s.shard(key).Set(key, value, duration)
+}
+
+func (s *Sharded[K, V]) Get(key K) (V, bool) {
+	if s == nil {
+		var zero V
+		return zero, false
+	}
+
+	return s.shard(key).Get(key)
+}
+
+func (s *Sharded[K, V]) Delete(key K) error {
+	if s == nil {
+		return ErrNilCache
+	}
+
+	return s.shard(key).Delete(key)
+}
+
+func (s *Sharded[K, V]) DeleteGet(key K) (V, error) {
+	if s == nil {
+		var zero V
+		return zero, ErrNilCache
+	}
+
+	return s.shard(key).DeleteGet(key)
+}
+
+// Update runs Update on every shard in turn; it is not atomic across
+// shards.
+func (s *Sharded[K, V]) Update(isUpdated func(v V) bool, update func(v V), duration time.Duration) {
+	if s == nil {
+		return
+	}
+
+	for _, shard := range s.shards {
+		shard.Update(isUpdated, update, duration)
+	}
+}
+
+// Range calls fn for the entries of each shard in turn, stopping when fn
+// returns false.
+func (s *Sharded[K, V]) Range(fn func(key K, value V) bool) {
+	if s == nil {
+		return
+	}
+
+	more := true
+	for _, shard := range s.shards {
+		shard.Range(func(key K, value V) bool {
+			more = fn(key, value)
+			return more
+		})
+		if !more {
+			return
+		}
+	}
+}
+
+// Stats sums the Stats of all shards.
+func (s *Sharded[K, V]) Stats() Stats {
+	if s == nil {
+		return Stats{}
+	}
+
+	total := Stats{Lock: make(map[string]LockStats, lockOpCount)}
+	for _, shard := range s.shards {
+		total.add(shard.Stats())
+	}
+
+	return total
+}
+
+func (s *Sharded[K, V]) Shards() int {
+	if s == nil {
+		return 0
+	}
+
+	return len(s.shards)
+}
+
+// Shutdown shuts every shard down, returning the errors of those that did
+// not stop before ctx expired.
+func (s *Sharded[K, V]) Shutdown(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	if s.expvar != "" {
+		unpublishExpvar(s.expvar, s)
+	}
+
+	var errs []error
+	for _, shard := range s.shards {
+		if err := shard.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close is Shutdown without a deadline, so the cache satisfies io.Closer.
+func (s *Sharded[K, V]) Close() error {
+	return s.Shutdown(context.Background())
+}
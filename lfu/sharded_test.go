@@ -0,0 +1,58 @@
+package lfu_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cache "github.com/grrrance/lfu-in-memory"
+	"github.com/grrrance/lfu-in-memory/cachetest"
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestShardedConformance(t *testing.T) {
+	cachetest.RunLFUConformance(t, func(capacity int, defaultTTL time.Duration) cache.InMemoryLFU {
+		c := lfu.NewShardedInMemoryCache(4, capacity, defaultTTL, 0)
+		t.Cleanup(func() { c.Shutdown(context.Background()) })
+		return c
+	})
+}
+
+func TestShardedSmallCapacityHasNoEmptyShard(t *testing.T) {
+	c := lfu.NewShardedInMemoryCache(4, 3, time.Hour, 0)
+	defer c.Shutdown(context.Background())
+
+	for i := 0; i < 20; i++ {
+		if err := c.Set(fmt.Sprint(i), i, 0); err != nil {
+			t.Fatalf("Set(%d) = %v", i, err)
+		}
+	}
+	if got := c.Stats().Capacity; got != 3 {
+		t.Errorf("capacity = %d, want 3", got)
+	}
+}
+
+func TestShardedCapacityOptionIsSplit(t *testing.T) {
+	c := lfu.NewShardedInMemoryCache(4, 1000, time.Hour, 0, lfu.WithCapacity(512))
+	defer c.Shutdown(context.Background())
+
+	if got := c.Stats().Capacity; got != 512 {
+		t.Errorf("capacity = %d, want 512", got)
+	}
+	if got := c.Shards(); got != 4 {
+		t.Errorf("shards = %d, want 4", got)
+	}
+}
+
+func TestShardedShardsHoldMinimumCapacity(t *testing.T) {
+	c := lfu.NewShardedInMemoryCache(16, 200, time.Hour, 0)
+	defer c.Shutdown(context.Background())
+
+	if got := c.Shards(); got != 2 {
+		t.Errorf("shards = %d, want 2", got)
+	}
+	if got := c.Stats().Capacity; got != 200 {
+		t.Errorf("capacity = %d, want 200", got)
+	}
+}
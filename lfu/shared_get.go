@@ -0,0 +1,68 @@
+package lfu
+
+import "sync/atomic"
+
+const sharedHitsBuffer = 1024
+
+// hitBuffer collects the keys of hits served under the read lock. Readers
+// claim distinct slots with one atomic add; the slots are only read and
+// reset by the holder of the exclusive lock, which no reader can overlap.
+type hitBuffer[K comparable] struct {
+	slots []K
+	next  atomic.Uint64
+}
+
+func (b *hitBuffer[K]) add(key K) bool {
+	i := b.next.Add(1) - 1
+	if i >= uint64(len(b.slots)) {
+		return false
+	}
+	b.slots[i] = key
+
+	return true
+}
+
+// getShared serves a lookup under the read lock and leaves the frequency
+// bump in sharedHits for the next holder of the exclusive lock to apply.
+// It reports !ok when the lookup has to modify the cache, or the buffer is
+// full, and must be retried under the exclusive lock.
+func (c *Cache[K, V]) getShared(key K) (value V, stale, found, ok bool) {
+	c.rlock(opGet)
+	defer c.RUnlock()
+
+	item, found := c.items[key]
+	if !found {
+		return value, false, false, c.overflow == nil
+	}
+
+	if c.isExpired(item) {
+		if c.inGrace(item.Expiration, item.deadline) {
+			value, stale, found = c.graceRead(item.Value)
+			return value, stale, found, true
+		}
+		return value, false, false, false
+	}
+
+	if !c.sharedHits.add(key) {
+		return value, false, false, false
+	}
+
+	return item.Value, false, true, true
+}
+
+func (c *Cache[K, V]) drainSharedHits() {
+	n := min(c.sharedHits.next.Load(), uint64(len(c.sharedHits.slots)))
+	if n == 0 {
+		return
+	}
+
+	// Expired entries are bumped too; they are reclaimed regardless.
+	var zero K
+	for i, key := range c.sharedHits.slots[:n] {
+		if item, ok := c.items[key]; ok {
+			c.upgradeItem(item, key)
+		}
+		c.sharedHits.slots[i] = zero
+	}
+	c.sharedHits.next.Store(0)
+}
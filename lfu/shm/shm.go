@@ -0,0 +1,52 @@
+// Package shm is an experimental LFU cache kept in a memory-mapped file, so
+// that several processes on one host can share it. Keys are strings and
+// values are byte slices, both limited to sizes fixed when the file is
+// created.
+//
+// The file is divided into sets of a few slots each and a key may only
+// live in the set its hash selects, so eviction removes the least
+// frequently used entry of that set rather than of the whole cache.
+// Every operation holds an exclusive lock on the file.
+package shm
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrClosed         = errors.New("Cache is closed")
+	ErrKeyTooLarge    = errors.New("Key is larger than the slot key size")
+	ErrValueTooLarge  = errors.New("Value is larger than the slot value size")
+	ErrLayoutMismatch = errors.New("File was created with a different layout")
+	ErrInvalidTTL     = errors.New("TTL must be positive")
+)
+
+const defaultWays = 8
+
+// Config fixes the layout of a new file; opening an existing file
+// requires the same Config. Slots is rounded up to a multiple of Ways,
+// which defaults to 8.
+type Config struct {
+	Slots     int
+	KeySize   int
+	ValueSize int
+	Ways      int
+}
+
+func (cfg Config) normalize() (Config, error) {
+	if cfg.Ways <= 0 {
+		cfg.Ways = defaultWays
+	}
+	if cfg.Slots <= 0 || cfg.KeySize <= 0 || cfg.KeySize > 1<<16-1 || cfg.ValueSize < 0 {
+		return cfg, errors.New("Invalid shared cache layout")
+	}
+	cfg.Slots = (cfg.Slots + cfg.Ways - 1) / cfg.Ways * cfg.Ways
+
+	return cfg, nil
+}
+
+// now is the wall clock shared by all processes, in Unix nanoseconds.
+func now() int64 {
+	return time.Now().UnixNano()
+}
@@ -0,0 +1,39 @@
+//go:build !unix
+
+package shm
+
+import (
+	"errors"
+	"time"
+)
+
+// Cache is unavailable on this platform; Open always fails.
+type Cache struct{}
+
+func Open(path string, cfg Config) (*Cache, error) {
+	if _, err := cfg.normalize(); err != nil {
+		return nil, err
+	}
+
+	return nil, errors.ErrUnsupported
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	return nil, false
+}
+
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) error {
+	return ErrClosed
+}
+
+func (c *Cache) Delete(key string) (bool, error) {
+	return false, ErrClosed
+}
+
+func (c *Cache) Len() int {
+	return 0
+}
+
+func (c *Cache) Close() error {
+	return nil
+}
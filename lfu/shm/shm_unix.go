@@ -0,0 +1,334 @@
+//go:build unix
+
+package shm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	magic      = "LFUSHM01"
+	version    = 1
+	headerSize = 64
+	slotHeader = 32
+)
+
+// Header layout: magic, version, ways, slots, key size, value size and the
+// access tick used to order entries of equal frequency.
+const (
+	offVersion   = 8
+	offWays      = 12
+	offSlots     = 16
+	offKeySize   = 24
+	offValueSize = 28
+	offTick      = 32
+)
+
+// Slot layout: used flag, key length, value length, frequency, expiry in
+// Unix nanoseconds and last access tick, followed by the key and value.
+const (
+	offUsed     = 0
+	offKeyLen   = 2
+	offValueLen = 4
+	offFreq     = 8
+	offExpires  = 16
+	offAccess   = 24
+)
+
+var le = binary.LittleEndian
+
+type Cache struct {
+	mu       sync.Mutex
+	file     *os.File
+	data     []byte
+	cfg      Config
+	slotSize int
+}
+
+// Open maps the cache file at path, creating and formatting it if it does
+// not exist or is empty.
+func Open(path string, cfg Config) (*Cache, error) {
+	cfg, err := cfg.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		file:     file,
+		cfg:      cfg,
+		slotSize: (slotHeader + cfg.KeySize + cfg.ValueSize + 7) &^ 7,
+	}
+	if err := c.mapFile(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Cache) mapFile() error {
+	if err := syscall.Flock(int(c.file.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+	defer syscall.Flock(int(c.file.Fd()), syscall.LOCK_UN)
+
+	size := headerSize + c.cfg.Slots*c.slotSize
+	info, err := c.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	fresh := info.Size() == 0
+	if fresh {
+		if err := c.file.Truncate(int64(size)); err != nil {
+			return err
+		}
+	} else if info.Size() != int64(size) {
+		return ErrLayoutMismatch
+	}
+
+	c.data, err = syscall.Mmap(int(c.file.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+
+	if fresh {
+		copy(c.data, magic)
+		le.PutUint32(c.data[offVersion:], version)
+		le.PutUint32(c.data[offWays:], uint32(c.cfg.Ways))
+		le.PutUint64(c.data[offSlots:], uint64(c.cfg.Slots))
+		le.PutUint32(c.data[offKeySize:], uint32(c.cfg.KeySize))
+		le.PutUint32(c.data[offValueSize:], uint32(c.cfg.ValueSize))
+		return nil
+	}
+
+	if string(c.data[:len(magic)]) != magic ||
+		le.Uint32(c.data[offVersion:]) != version ||
+		le.Uint32(c.data[offWays:]) != uint32(c.cfg.Ways) ||
+		le.Uint64(c.data[offSlots:]) != uint64(c.cfg.Slots) ||
+		le.Uint32(c.data[offKeySize:]) != uint32(c.cfg.KeySize) ||
+		le.Uint32(c.data[offValueSize:]) != uint32(c.cfg.ValueSize) {
+		syscall.Munmap(c.data)
+		c.data = nil
+		return ErrLayoutMismatch
+	}
+
+	return nil
+}
+
+// lock takes the in-process mutex and then the file lock, which does not
+// exclude goroutines sharing the same descriptor.
+func (c *Cache) lock() error {
+	c.mu.Lock()
+	if c.data == nil {
+		c.mu.Unlock()
+		return ErrClosed
+	}
+
+	if err := syscall.Flock(int(c.file.Fd()), syscall.LOCK_EX); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+func (c *Cache) unlock() {
+	syscall.Flock(int(c.file.Fd()), syscall.LOCK_UN)
+	c.mu.Unlock()
+}
+
+func (c *Cache) slot(i int) []byte {
+	off := headerSize + i*c.slotSize
+	return c.data[off : off+c.slotSize]
+}
+
+func (c *Cache) tick() uint64 {
+	t := le.Uint64(c.data[offTick:]) + 1
+	le.PutUint64(c.data[offTick:], t)
+
+	return t
+}
+
+// fnv64a hashes key identically in every process.
+func fnv64a(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+
+	return h
+}
+
+// find returns the slot holding key, or -1, and the first slot of its set.
+func (c *Cache) find(key string) (int, int) {
+	sets := c.cfg.Slots / c.cfg.Ways
+	first := int(fnv64a(key)%uint64(sets)) * c.cfg.Ways
+
+	for i := first; i < first+c.cfg.Ways; i++ {
+		s := c.slot(i)
+		if s[offUsed] == 0 {
+			continue
+		}
+		n := int(le.Uint16(s[offKeyLen:]))
+		if n == len(key) && string(s[slotHeader:slotHeader+n]) == key {
+			return i, first
+		}
+	}
+
+	return -1, first
+}
+
+func expired(s []byte, at int64) bool {
+	return int64(le.Uint64(s[offExpires:])) <= at
+}
+
+// Get returns a copy of the value stored under key.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	if c.lock() != nil {
+		return nil, false
+	}
+	defer c.unlock()
+
+	i, _ := c.find(key)
+	if i < 0 {
+		return nil, false
+	}
+
+	s := c.slot(i)
+	if expired(s, now()) {
+		s[offUsed] = 0
+		return nil, false
+	}
+
+	le.PutUint64(s[offFreq:], le.Uint64(s[offFreq:])+1)
+	le.PutUint64(s[offAccess:], c.tick())
+
+	value := s[slotHeader+c.cfg.KeySize:]
+	return bytes.Clone(value[:le.Uint32(s[offValueLen:])]), true
+}
+
+// Set stores a copy of value under key for ttl, returning ErrInvalidTTL
+// unless it is positive. A new key takes a free or expired slot of its
+// set, or else evicts the set's least frequently used entry, the least
+// recently used among equals.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return ErrInvalidTTL
+	}
+	if len(key) > c.cfg.KeySize {
+		return ErrKeyTooLarge
+	}
+	if len(value) > c.cfg.ValueSize {
+		return ErrValueTooLarge
+	}
+
+	if err := c.lock(); err != nil {
+		return err
+	}
+	defer c.unlock()
+
+	at := now()
+	i, first := c.find(key)
+	if i < 0 {
+		i = c.victim(first, at)
+		s := c.slot(i)
+		s[offUsed] = 1
+		le.PutUint16(s[offKeyLen:], uint16(len(key)))
+		le.PutUint64(s[offFreq:], 0)
+		copy(s[slotHeader:], key)
+	}
+
+	s := c.slot(i)
+	le.PutUint32(s[offValueLen:], uint32(len(value)))
+	le.PutUint64(s[offFreq:], le.Uint64(s[offFreq:])+1)
+	le.PutUint64(s[offExpires:], uint64(at+int64(ttl)))
+	le.PutUint64(s[offAccess:], c.tick())
+	copy(s[slotHeader+c.cfg.KeySize:], value)
+
+	return nil
+}
+
+func (c *Cache) victim(first int, at int64) int {
+	best := -1
+	var bestFreq, bestAccess uint64
+	for i := first; i < first+c.cfg.Ways; i++ {
+		s := c.slot(i)
+		if s[offUsed] == 0 || expired(s, at) {
+			return i
+		}
+
+		freq, access := le.Uint64(s[offFreq:]), le.Uint64(s[offAccess:])
+		if best < 0 || freq < bestFreq || (freq == bestFreq && access < bestAccess) {
+			best, bestFreq, bestAccess = i, freq, access
+		}
+	}
+
+	return best
+}
+
+// Delete removes key, reporting whether it was present.
+func (c *Cache) Delete(key string) (bool, error) {
+	if err := c.lock(); err != nil {
+		return false, err
+	}
+	defer c.unlock()
+
+	i, _ := c.find(key)
+	if i < 0 {
+		return false, nil
+	}
+	s := c.slot(i)
+	live := !expired(s, now())
+	s[offUsed] = 0
+
+	return live, nil
+}
+
+// Len counts the live entries, scanning every slot.
+func (c *Cache) Len() int {
+	if c.lock() != nil {
+		return 0
+	}
+	defer c.unlock()
+
+	at := now()
+	var n int
+	for i := 0; i < c.cfg.Slots; i++ {
+		if s := c.slot(i); s[offUsed] != 0 && !expired(s, at) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Close unmaps the file. The entries stay in it for other processes and
+// later Opens.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data == nil {
+		return nil
+	}
+
+	err := syscall.Munmap(c.data)
+	c.data = nil
+	if cerr := c.file.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
@@ -0,0 +1,62 @@
+//go:build unix
+
+package shm_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu/shm"
+)
+
+func open(t *testing.T, path string) *shm.Cache {
+	t.Helper()
+
+	c, err := shm.Open(path, shm.Config{Slots: 8, KeySize: 16, ValueSize: 32})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+func TestSharedBetweenHandles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache")
+	a, b := open(t, path), open(t, path)
+
+	if err := a.Set("k", []byte("v"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := b.Get("k"); !ok || string(v) != "v" {
+		t.Fatalf("Get = %q, %v, want v, true", v, ok)
+	}
+}
+
+func TestSetRejectsNonPositiveTTL(t *testing.T) {
+	c := open(t, filepath.Join(t.TempDir(), "cache"))
+
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		if err := c.Set("k", []byte("v"), ttl); err != shm.ErrInvalidTTL {
+			t.Errorf("Set with ttl %v = %v, want ErrInvalidTTL", ttl, err)
+		}
+	}
+	if c.Len() != 0 {
+		t.Fatal("rejected entry was stored")
+	}
+}
+
+func TestEvictsLeastFrequentOfSet(t *testing.T) {
+	c := open(t, filepath.Join(t.TempDir(), "cache"))
+
+	c.Set("hot", []byte("h"), time.Minute)
+	c.Get("hot")
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		c.Set(key, []byte(key), time.Minute)
+	}
+
+	if _, ok := c.Get("hot"); !ok {
+		t.Fatal("most frequently used entry was evicted")
+	}
+}
@@ -0,0 +1,54 @@
+package lfu
+
+import "context"
+
+// Shutdown stops the background maintenance goroutines, waits for them to
+// exit (including delivery of queued webhook events) and applies any
+// buffered frequency updates. If ctx expires first the
+// context error is returned; the goroutines still exit on their own.
+// Calling Shutdown more than once, or on a nil cache, is a no-op.
+func (c *Cache[K, V]) Shutdown(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.lock(opShutdown)
+	if c.closed.Load() {
+		c.unlock()
+		return nil
+	}
+	c.closed.Store(true)
+	close(c.done)
+	for key, l := range c.leases {
+		c.endLease(key, l)
+	}
+	c.unlock()
+
+	unregister(c)
+	if c.expvarName != "" {
+		unpublishExpvar(c.expvarName, c)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		c.workers.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	c.lock(opShutdown)
+	c.drainHits()
+	c.unlock()
+
+	return nil
+}
+
+// Close is Shutdown without a deadline, so the cache satisfies io.Closer.
+func (c *Cache[K, V]) Close() error {
+	return c.Shutdown(context.Background())
+}
@@ -0,0 +1,54 @@
+package lfu
+
+import (
+	"log/slog"
+	"time"
+)
+
+// WithSlowOpThreshold logs, at warning level, every Get, Set, Delete,
+// Update, TouchMany, SetMany, Range, GetOrLoad, namespace refresh and sweep
+// that takes longer than d. The time includes waiting for the lock and running
+// callbacks, loaders and hooks. A nil logger selects slog.Default.
+func WithSlowOpThreshold(d time.Duration, logger *slog.Logger) Option {
+	return func(c *settings) {
+		if d <= 0 {
+			return
+		}
+		if logger == nil {
+			logger = slog.Default()
+		}
+		c.slowThreshold = d
+		c.slowLogger = logger
+	}
+}
+
+// slowStart returns the start time of an operation, or the zero time when
+// slow operations are not logged.
+func (c *Cache[K, V]) slowStart() time.Time {
+	if c == nil || c.slowThreshold <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now()
+}
+
+func (c *Cache[K, V]) slowOp(op string, start time.Time, args ...any) {
+	if start.IsZero() {
+		return
+	}
+
+	if d := time.Since(start); d > c.slowThreshold {
+		args = append([]any{"cache", c.name, "op", op, "duration", d}, args...)
+		c.slowLogger.Warn("slow cache operation", args...)
+	}
+}
+
+func (c *Cache[K, V]) slowKeyOp(op string, key K, start time.Time) {
+	if start.IsZero() {
+		return
+	}
+
+	if d := time.Since(start); d > c.slowThreshold {
+		c.slowLogger.Warn("slow cache operation", "cache", c.name, "op", op, "key", keyText(key), "duration", d)
+	}
+}
@@ -0,0 +1,90 @@
+package lfu
+
+import "sync/atomic"
+
+// Stats describes the cache at one point in time. The counters accumulate
+// from creation: Hits and Misses count lookups, grace reads included in
+// Hits; Sets counts stored and replaced values; Deletes, Evictions and
+// Expirations count entries removed explicitly, for capacity or coldness,
+// and for running out of TTL. Levels holds the number of entries at each
+// frequency level under WithFrequencyLevels and is nil otherwise.
+// LoaderTimeouts counts loader calls cut off by WithLoaderTimeout, and
+// MissPenalty is the time spent loading misses.
+type Stats struct {
+	Len            int
+	Cost           int
+	Capacity       int
+	Overflow       int
+	Hits           uint64
+	Misses         uint64
+	Sets           uint64
+	Deletes        uint64
+	Evictions      uint64
+	Expirations    uint64
+	GraceReads     uint64
+	LoaderTimeouts uint64
+	Levels         []int
+	MissPenalty    MissPenalty
+	Lock           map[string]LockStats
+}
+
+type statsCounters struct {
+	hits           atomic.Uint64
+	misses         atomic.Uint64
+	sets           atomic.Uint64
+	deletes        atomic.Uint64
+	evictions      atomic.Uint64
+	expirations    atomic.Uint64
+	graceReads     atomic.Uint64
+	loaderTimeouts atomic.Uint64
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+
+	c.rlock(opStats)
+	stats := Stats{
+		Len:      len(c.items),
+		Cost:     c.cost,
+		Capacity: c.size,
+		Levels:   c.levelCounts(),
+	}
+	if c.overflow != nil {
+		stats.Overflow = c.overflow.list.Len()
+	}
+	c.RUnlock()
+
+	stats.Hits = c.counters.hits.Load()
+	stats.Misses = c.counters.misses.Load()
+	stats.Sets = c.counters.sets.Load()
+	stats.Deletes = c.counters.deletes.Load()
+	stats.Evictions = c.counters.evictions.Load()
+	stats.Expirations = c.counters.expirations.Load()
+	stats.GraceReads = c.counters.graceReads.Load()
+	stats.LoaderTimeouts = c.counters.loaderTimeouts.Load()
+	stats.Lock = c.lockSnapshot()
+	stats.MissPenalty = c.missPenalty()
+
+	return stats
+}
+
+func (c *Cache[K, V]) countLookup(found bool) {
+	if found {
+		c.counters.hits.Add(1)
+	} else {
+		c.counters.misses.Add(1)
+	}
+}
+
+func (c *Cache[K, V]) countRemoval(reason EvictionReason) {
+	switch reason {
+	case ReasonDelete:
+		c.counters.deletes.Add(1)
+	case ReasonExpired:
+		c.counters.expirations.Add(1)
+	case ReasonCapacity, ReasonCold:
+		c.counters.evictions.Add(1)
+	}
+}
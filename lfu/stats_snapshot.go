@@ -0,0 +1,141 @@
+package lfu
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// StatsSnapshotVersion is bumped whenever a field of StatsSnapshot changes
+// meaning or is removed. Adding fields does not bump it.
+const StatsSnapshotVersion = 1
+
+// StatsSnapshot is the JSON form of Stats, meant to be embedded in health
+// or metrics documents. Durations are encoded as integer nanoseconds.
+type StatsSnapshot struct {
+	Version        int                          `json:"version"`
+	Len            int                          `json:"len"`
+	Cost           int                          `json:"cost"`
+	Capacity       int                          `json:"capacity"`
+	Overflow       int                          `json:"overflow"`
+	Hits           uint64                       `json:"hits"`
+	Misses         uint64                       `json:"misses"`
+	Sets           uint64                       `json:"sets"`
+	Deletes        uint64                       `json:"deletes"`
+	Evictions      uint64                       `json:"evictions"`
+	Expirations    uint64                       `json:"expirations"`
+	GraceReads     uint64                       `json:"grace_reads"`
+	LoaderTimeouts uint64                       `json:"loader_timeouts"`
+	Levels         []int                        `json:"levels"`
+	MissPenalty    MissPenaltySnapshot          `json:"miss_penalty"`
+	Lock           map[string]LockStatsSnapshot `json:"lock"`
+}
+
+type MissPenaltySnapshot struct {
+	Loads             uint64                              `json:"loads"`
+	TotalNs           int64                               `json:"total_ns"`
+	WindowNs          int64                               `json:"window_ns"`
+	LastWindowLoads   uint64                              `json:"last_window_loads"`
+	LastWindowTotalNs int64                               `json:"last_window_total_ns"`
+	Namespaces        map[string]NamespacePenaltySnapshot `json:"namespaces"`
+}
+
+type NamespacePenaltySnapshot struct {
+	Loads             uint64 `json:"loads"`
+	TotalNs           int64  `json:"total_ns"`
+	LastWindowLoads   uint64 `json:"last_window_loads"`
+	LastWindowTotalNs int64  `json:"last_window_total_ns"`
+}
+
+type LockStatsSnapshot struct {
+	Acquisitions  uint64   `json:"acquisitions"`
+	Contended     uint64   `json:"contended"`
+	WaitTotalNs   int64    `json:"wait_total_ns"`
+	WaitBucketsNs []int64  `json:"wait_buckets_ns"`
+	WaitHistogram []uint64 `json:"wait_histogram"`
+}
+
+func (s Stats) Snapshot() StatsSnapshot {
+	snapshot := StatsSnapshot{
+		Version:        StatsSnapshotVersion,
+		Len:            s.Len,
+		Cost:           s.Cost,
+		Capacity:       s.Capacity,
+		Overflow:       s.Overflow,
+		Hits:           s.Hits,
+		Misses:         s.Misses,
+		Sets:           s.Sets,
+		Deletes:        s.Deletes,
+		Evictions:      s.Evictions,
+		Expirations:    s.Expirations,
+		GraceReads:     s.GraceReads,
+		LoaderTimeouts: s.LoaderTimeouts,
+		Levels:         s.Levels,
+		MissPenalty:    s.MissPenalty.snapshot(),
+		Lock:           make(map[string]LockStatsSnapshot, len(s.Lock)),
+	}
+
+	for op, lock := range s.Lock {
+		snapshot.Lock[op] = lock.snapshot()
+	}
+
+	return snapshot
+}
+
+func (p MissPenalty) snapshot() MissPenaltySnapshot {
+	snapshot := MissPenaltySnapshot{
+		Loads:             p.Total.Loads,
+		TotalNs:           int64(p.Total.Time),
+		WindowNs:          int64(p.Window),
+		LastWindowLoads:   p.LastWindow.Loads,
+		LastWindowTotalNs: int64(p.LastWindow.Time),
+		Namespaces:        make(map[string]NamespacePenaltySnapshot, len(p.Namespaces)),
+	}
+
+	for ns, np := range p.Namespaces {
+		snapshot.Namespaces[ns] = NamespacePenaltySnapshot{
+			Loads:             np.Total.Loads,
+			TotalNs:           int64(np.Total.Time),
+			LastWindowLoads:   np.LastWindow.Loads,
+			LastWindowTotalNs: int64(np.LastWindow.Time),
+		}
+	}
+
+	return snapshot
+}
+
+func (s LockStats) snapshot() LockStatsSnapshot {
+	snapshot := LockStatsSnapshot{
+		Acquisitions:  s.Acquisitions,
+		Contended:     s.Contended,
+		WaitTotalNs:   int64(s.WaitTotal),
+		WaitBucketsNs: make([]int64, len(LockWaitBuckets)),
+		WaitHistogram: append([]uint64(nil), s.WaitHistogram[:]...),
+	}
+
+	for i, bound := range LockWaitBuckets {
+		snapshot.WaitBucketsNs[i] = int64(bound / time.Nanosecond)
+	}
+
+	return snapshot
+}
+
+// MarshalJSON always emits every field, stamping the current version on
+// snapshots that were built by hand without one.
+func (s StatsSnapshot) MarshalJSON() ([]byte, error) {
+	type plain StatsSnapshot
+
+	if s.Version == 0 {
+		s.Version = StatsSnapshotVersion
+	}
+	if s.Levels == nil {
+		s.Levels = []int{}
+	}
+	if s.MissPenalty.Namespaces == nil {
+		s.MissPenalty.Namespaces = map[string]NamespacePenaltySnapshot{}
+	}
+	if s.Lock == nil {
+		s.Lock = map[string]LockStatsSnapshot{}
+	}
+
+	return json.Marshal(plain(s))
+}
@@ -0,0 +1,30 @@
+package lfu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestStatsSharesLockWithReaders(t *testing.T) {
+	c := lfu.NewInMemoryCache(4, time.Hour, 0)
+	defer c.Shutdown(context.Background())
+	c.Set("a", 1, 0)
+
+	c.RLock()
+	defer c.RUnlock()
+
+	done := make(chan lfu.Stats, 1)
+	go func() { done <- c.Stats() }()
+
+	select {
+	case stats := <-done:
+		if stats.Len != 1 {
+			t.Fatalf("Len = %d, want 1", stats.Len)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stats waited for a reader to release the lock")
+	}
+}
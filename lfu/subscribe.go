@@ -0,0 +1,56 @@
+package lfu
+
+import "strings"
+
+type subscription struct {
+	id      uint64
+	match   func(key string) bool
+	handler func(WebhookEvent)
+}
+
+// Subscribe calls handler for every eviction, expiration, hot-key and
+// rename event whose key satisfies match; hot-key events need a HotKeyFrequency set
+// through WithWebhook. Handlers run on the goroutine that caused the event,
+// after the cache lock is released. Keys that are not strings are matched
+// as formatted by fmt.Sprint. The returned function cancels the
+// subscription.
+func (c *Cache[K, V]) Subscribe(match func(key string) bool, handler func(WebhookEvent)) (unsubscribe func()) {
+	if c.usable() != nil || c.checkCallbacks(match == nil || handler == nil) != nil {
+		return func() {}
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	c.subscriptionID++
+	id := c.subscriptionID
+	c.subscriptions = append(c.subscriptions, subscription{id: id, match: match, handler: handler})
+
+	return func() {
+		c.lock(opSet)
+		defer c.unlock()
+
+		for i, sub := range c.subscriptions {
+			if sub.id == id {
+				c.subscriptions = append(c.subscriptions[:i:i], c.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// SubscribePrefix is Subscribe for the keys starting with prefix.
+func (c *Cache[K, V]) SubscribePrefix(prefix string, handler func(WebhookEvent)) (unsubscribe func()) {
+	return c.Subscribe(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	}, handler)
+}
+
+func (c *Cache[K, V]) notify(event WebhookEvent) {
+	for _, sub := range c.subscriptions {
+		if sub.match(event.Key) {
+			handler := sub.handler
+			c.afterUnlock(func() { handler(event) })
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package lfu
+
+import "reflect"
+
+// WithWriteSuppression makes Set a no-op when the key holds a live value
+// equal to the new one: neither the value, the frequency nor the expiration
+// change. equal decides equality; when nil, values are compared with == when
+// both are comparable at run time.
+func WithWriteSuppression(equal func(old, new interface{}) bool) Option {
+	return func(c *settings) {
+		if equal == nil {
+			equal = comparableEqual
+		}
+		c.equal = equal
+	}
+}
+
+func comparableEqual(old, new interface{}) bool {
+	if old == nil || new == nil {
+		return old == new
+	}
+
+	o, n := reflect.ValueOf(old), reflect.ValueOf(new)
+	if o.Type() != n.Type() || !o.Comparable() || !n.Comparable() {
+		return false
+	}
+
+	return old == new
+}
+
+func (c *Cache[K, V]) suppressed(item *Item[K, V], value V) bool {
+	return c.equal != nil && !c.isExpired(item) && c.equal(any(item.Value), any(value))
+}
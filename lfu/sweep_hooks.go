@@ -0,0 +1,25 @@
+package lfu
+
+import "time"
+
+// SweepReport describes one maintenance sweep. The pre hook receives only
+// Started and Len, the number of entries before the sweep; the post hook
+// receives the full report with Len counted after the sweep. Reclaimed is
+// in the same cost units as the cache size.
+type SweepReport struct {
+	Started   time.Time
+	Duration  time.Duration
+	Len       int
+	Removed   int
+	Reclaimed int
+}
+
+// WithSweepHooks registers functions called before and after every
+// maintenance sweep, on the maintenance goroutine and without the cache
+// lock held, so they may use the cache. Either may be nil.
+func WithSweepHooks(pre, post func(SweepReport)) Option {
+	return func(c *settings) {
+		c.preSweep = pre
+		c.postSweep = post
+	}
+}
@@ -0,0 +1,85 @@
+package lfu
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// TieBreak selects the capacity victim among entries of the lowest
+// frequency.
+type TieBreak int
+
+const (
+	// TieBreakLRU evicts the least recently used entry.
+	TieBreakLRU TieBreak = iota
+	// TieBreakFIFO evicts the entry stored earliest, regardless of use.
+	TieBreakFIFO
+	// TieBreakRandom evicts a random entry.
+	TieBreakRandom
+)
+
+// WithTieBreak sets how eviction chooses between entries of equal
+// frequency. The default is TieBreakLRU. FIFO and random selection scan
+// the lowest bucket, so they cost time linear in its size.
+func WithTieBreak(policy TieBreak) Option {
+	return func(c *settings) {
+		c.tieBreak = policy
+	}
+}
+
+// pick returns the victim in b other than spare, or nil if there is none:
+// the entry of lowest priority, ties broken by the tie-break policy.
+func (c *Cache[K, V]) pick(b *bucket[K, V], spare K) *Item[K, V] {
+	if c.prioritized == 0 && c.tieBreak == TieBreakLRU {
+		for item := b.head; item != nil; item = item.next {
+			if item.key != spare {
+				return item
+			}
+		}
+		return nil
+	}
+
+	var (
+		best *Item[K, V]
+		ties int
+	)
+	for item := b.head; item != nil; item = item.next {
+		switch {
+		case item.key == spare:
+		case best == nil || item.priority < best.priority:
+			best, ties = item, 1
+		case item.priority == best.priority:
+			ties++
+			switch c.tieBreak {
+			case TieBreakFIFO:
+				if item.inserted < best.inserted {
+					best = item
+				}
+			case TieBreakRandom:
+				if rand.Intn(ties) == 0 {
+					best = item
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+// ordered returns the items of b in the order pick would take them under a
+// deterministic policy.
+func (c *Cache[K, V]) ordered(b *bucket[K, V]) []*Item[K, V] {
+	items := make([]*Item[K, V], 0, b.size)
+	for item := b.head; item != nil; item = item.next {
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].priority != items[j].priority {
+			return items[i].priority < items[j].priority
+		}
+		return c.tieBreak == TieBreakFIFO && items[i].inserted < items[j].inserted
+	})
+
+	return items
+}
@@ -0,0 +1,132 @@
+package lfu
+
+import (
+	"sync"
+	"time"
+)
+
+// TimeBuckets stores entries in buckets of a fixed time width and keeps
+// only the most recent few. Entries carry no TTL of their own: a whole
+// bucket is dropped at once when time moves past it, which suits
+// append-heavy, time-scoped data such as recently seen request IDs.
+type TimeBuckets[K comparable, V any] struct {
+	mu      sync.Mutex
+	clock   Clock
+	width   time.Duration
+	buckets []timeBucket[K, V]
+}
+
+type timeBucket[K comparable, V any] struct {
+	index   int64
+	entries map[K]V
+}
+
+// NewTimeBuckets keeps keep buckets of the given width, so entries live
+// between (keep-1)*width and keep*width. A nil clock selects the system
+// clock.
+func NewTimeBuckets[K comparable, V any](width time.Duration, keep int, clock Clock) *TimeBuckets[K, V] {
+	if width <= 0 {
+		width = time.Second
+	}
+	if keep <= 0 {
+		keep = 1
+	}
+	if clock == nil {
+		clock = systemClock{origin: time.Now()}
+	}
+
+	return &TimeBuckets[K, V]{
+		clock:   clock,
+		width:   width,
+		buckets: make([]timeBucket[K, V], keep),
+	}
+}
+
+// current returns the bucket for now, dropping every bucket that has aged
+// out.
+func (t *TimeBuckets[K, V]) current() (*timeBucket[K, V], int64) {
+	index := t.clock.Now().UnixNano() / int64(t.width)
+	for i := range t.buckets {
+		if bucket := &t.buckets[i]; !t.live(bucket, index) {
+			bucket.entries = nil
+		}
+	}
+
+	bucket := &t.buckets[t.slot(index)]
+	if bucket.entries == nil || bucket.index != index {
+		bucket.index = index
+		bucket.entries = make(map[K]V)
+	}
+
+	return bucket, index
+}
+
+func (t *TimeBuckets[K, V]) slot(index int64) int {
+	slot := int(index % int64(len(t.buckets)))
+	if slot < 0 {
+		slot += len(t.buckets)
+	}
+
+	return slot
+}
+
+func (t *TimeBuckets[K, V]) live(bucket *timeBucket[K, V], now int64) bool {
+	return bucket.entries != nil && now-bucket.index < int64(len(t.buckets))
+}
+
+// Set stores value in the current bucket. A key already held by an older
+// bucket moves to the current one.
+func (t *TimeBuckets[K, V]) Set(key K, value V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket, now := t.current()
+	for i := range t.buckets {
+		if other := &t.buckets[i]; other != bucket && t.live(other, now) {
+			delete(other.entries, key)
+		}
+	}
+	bucket.entries[key] = value
+}
+
+func (t *TimeBuckets[K, V]) Get(key K) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, now := t.current()
+	for i := range t.buckets {
+		if bucket := &t.buckets[i]; t.live(bucket, now) {
+			if value, found := bucket.entries[key]; found {
+				return value, true
+			}
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (t *TimeBuckets[K, V]) Delete(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.buckets {
+		delete(t.buckets[i].entries, key)
+	}
+}
+
+// Len counts the entries in live buckets.
+func (t *TimeBuckets[K, V]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, now := t.current()
+	var n int
+	for i := range t.buckets {
+		if bucket := &t.buckets[i]; t.live(bucket, now) {
+			n += len(bucket.entries)
+		}
+	}
+
+	return n
+}
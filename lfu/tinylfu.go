@@ -0,0 +1,135 @@
+package lfu
+
+import (
+	"hash/maphash"
+	"sync/atomic"
+)
+
+const (
+	sketchDepth    = 4
+	sketchMax      = 15
+	sketchMinWidth = 16
+	// sketchSample is the number of recorded accesses, per counter in a
+	// row, after which all counters are halved so old popularity fades.
+	sketchSample = 10
+)
+
+// WithTinyLFU admits a new key into a full cache only if it has been
+// accessed more often recently than the entry it would evict. Accesses of
+// every key, cached or not, are counted approximately in a count-min
+// sketch that is periodically aged. A rejected Set returns ErrNotAdmitted
+// and SetMany reports SetNotAdmitted.
+func WithTinyLFU() Option {
+	return func(c *settings) {
+		c.tinyLFU = true
+	}
+}
+
+// sketch is a count-min sketch of access frequencies. Counters are updated
+// atomically, so accesses can be recorded without the cache lock.
+type sketch struct {
+	seed      maphash.Seed
+	counters  []atomic.Uint32
+	mask      uint32
+	additions atomic.Uint64
+	sample    uint64
+}
+
+func newSketch(size int) *sketch {
+	width := sketchMinWidth
+	for width < size {
+		width <<= 1
+	}
+
+	return &sketch{
+		seed:     maphash.MakeSeed(),
+		counters: make([]atomic.Uint32, sketchDepth*width),
+		mask:     uint32(width - 1),
+		sample:   uint64(sketchSample * width),
+	}
+}
+
+func (s *sketch) slot(h uint64, row int) *atomic.Uint32 {
+	i := (uint32(h) + uint32(row)*uint32(h>>32)) & s.mask
+	return &s.counters[row*int(s.mask+1)+int(i)]
+}
+
+func (s *sketch) add(h uint64) {
+	for row := 0; row < sketchDepth; row++ {
+		counter := s.slot(h, row)
+		for {
+			n := counter.Load()
+			if n >= sketchMax || counter.CompareAndSwap(n, n+1) {
+				break
+			}
+		}
+	}
+
+	if s.additions.Add(1) == s.sample {
+		s.age()
+	}
+}
+
+func (s *sketch) estimate(h uint64) uint32 {
+	least := uint32(sketchMax)
+	for row := 0; row < sketchDepth; row++ {
+		if n := s.slot(h, row).Load(); n < least {
+			least = n
+		}
+	}
+
+	return least
+}
+
+// age halves every counter. Increments racing with it may be lost, which
+// only makes the estimates slightly lower.
+func (s *sketch) age() {
+	for i := range s.counters {
+		counter := &s.counters[i]
+		for {
+			n := counter.Load()
+			if counter.CompareAndSwap(n, n/2) {
+				break
+			}
+		}
+	}
+	s.additions.Store(0)
+}
+
+func hashKey[K comparable](seed maphash.Seed, key K) uint64 {
+	if k, ok := any(key).(string); ok {
+		return maphash.String(seed, k)
+	}
+
+	return maphash.String(seed, keyText(key))
+}
+
+func (c *Cache[K, V]) sketchHash(key K) uint64 {
+	return hashKey(c.sketch.seed, key)
+}
+
+func (c *Cache[K, V]) recordAccess(key K) {
+	if c.sketch != nil {
+		c.sketch.add(c.sketchHash(key))
+	}
+}
+
+// admit reports whether a new entry for key costing cost may displace the
+// next victim.
+func (c *Cache[K, V]) admit(key K, cost int) bool {
+	if c.sketch == nil || c.cost+cost <= c.size {
+		return true
+	}
+
+	c.removeReapable()
+	if c.cost+cost <= c.size {
+		return true
+	}
+
+	victim, ok := c.victim(key)
+	if !ok {
+		return true
+	}
+
+	return c.sketch.estimate(c.sketchHash(key)) > c.sketch.estimate(c.sketchHash(victim))
+}
@@ -0,0 +1,35 @@
+package lfu
+
+import "time"
+
+// TouchMany resets the expiration of every live key in keys to ttl from now
+// under a single lock acquisition and returns how many keys were found.
+// Frequencies are left untouched.
+func (c *Cache[K, V]) TouchMany(keys []K, ttl time.Duration) int {
+	defer c.slowOp("touch", c.slowStart())
+
+	if c.writable() != nil {
+		return 0
+	}
+	c.checkTTL(ttl)
+
+	c.lock(opTouch)
+	defer c.unlock()
+
+	c.drainHits()
+
+	var touched int
+	for _, key := range keys {
+		item, found := c.items[key]
+		if !found || c.isExpired(item) {
+			continue
+		}
+
+		c.setExp(item, ttl)
+		c.audit("", auditTouch, key, nil)
+		c.publish(key, item)
+		touched++
+	}
+
+	return touched
+}
@@ -0,0 +1,32 @@
+package lfu
+
+import (
+	"reflect"
+	"time"
+)
+
+// WithTypeTTL sets default expirations by the dynamic type of the value.
+// They apply whenever a write passes a non-positive duration; values of
+// other types fall back to the cache's default expiration.
+func WithTypeTTL(ttls map[reflect.Type]time.Duration) Option {
+	return func(c *settings) {
+		c.typeTTL = make(map[reflect.Type]time.Duration, len(ttls))
+		for t, ttl := range ttls {
+			if ttl > 0 {
+				c.typeTTL[t] = ttl
+			}
+		}
+	}
+}
+
+func (c *Cache[K, V]) typeDuration(value V) time.Duration {
+	if len(c.typeTTL) == 0 {
+		return c.defaultExpiration
+	}
+
+	if ttl, ok := c.typeTTL[reflect.TypeOf(any(value))]; ok {
+		return ttl
+	}
+
+	return c.defaultExpiration
+}
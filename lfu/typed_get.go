@@ -0,0 +1,95 @@
+package lfu
+
+import (
+	"math"
+	"time"
+)
+
+// GetString is Get for a string value. Like the other typed getters, it
+// reports false when the value has another type, and counts a hit on key
+// whenever the key itself is found.
+func (c *Cache[K, V]) GetString(key K) (string, bool) {
+	value, found := c.Get(key)
+	if !found {
+		return "", false
+	}
+
+	s, ok := any(value).(string)
+
+	return s, ok
+}
+
+// GetBytes is Get for a []byte value. The slice is the cached one and must
+// not be modified.
+func (c *Cache[K, V]) GetBytes(key K) ([]byte, bool) {
+	value, found := c.Get(key)
+	if !found {
+		return nil, false
+	}
+
+	b, ok := any(value).([]byte)
+
+	return b, ok
+}
+
+// GetTime is Get for a time.Time value.
+func (c *Cache[K, V]) GetTime(key K) (time.Time, bool) {
+	value, found := c.Get(key)
+	if !found {
+		return time.Time{}, false
+	}
+
+	t, ok := any(value).(time.Time)
+
+	return t, ok
+}
+
+// GetInt64 is Get for a value of any integer type, or a float holding a
+// whole number, that fits in an int64.
+func (c *Cache[K, V]) GetInt64(key K) (int64, bool) {
+	value, found := c.Get(key)
+	if !found {
+		return 0, false
+	}
+
+	return toInt64(any(value))
+}
+
+func toInt64(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), uint64(v) <= math.MaxInt64
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case float32:
+		return floatToInt64(float64(v))
+	case float64:
+		return floatToInt64(v)
+	}
+
+	return 0, false
+}
+
+func floatToInt64(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+
+	return int64(f), true
+}
@@ -0,0 +1,108 @@
+package lfu
+
+import "time"
+
+// Progress reports how far an UpdateProgressive run has got. The last value
+// sent has Done set; it is also sent when the run stops early because the
+// cache was shut down.
+type Progress struct {
+	Total     int
+	Processed int
+	Updated   int
+	Done      bool
+}
+
+// UpdateProgressive behaves like Update but works through the keys present
+// at the time of the call in batches of batchSize, releasing the lock
+// between batches so other operations are not blocked for the whole run.
+// One Progress value is sent per batch and the channel is closed afterwards.
+// The channel is buffered for every report, so it need not be drained.
+func (c *Cache[K, V]) UpdateProgressive(isUpdated func(v V) bool, update func(v V), duration time.Duration, batchSize int) <-chan Progress {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	if c.writable() != nil || c.checkCallbacks(isUpdated == nil || update == nil) != nil {
+		return finishedProgress()
+	}
+	c.checkTTL(duration)
+
+	c.lock(opUpdate)
+	if c.closed.Load() {
+		c.unlock()
+		return finishedProgress()
+	}
+	keys := make([]K, 0, len(c.items))
+	for key := range c.items {
+		keys = append(keys, key)
+	}
+	c.workers.Add(1)
+	c.unlock()
+
+	progress := make(chan Progress, len(keys)/batchSize+1)
+
+	go func() {
+		defer c.workers.Done()
+		defer close(progress)
+
+		report := Progress{Total: len(keys)}
+		for start := 0; start < len(keys); start += batchSize {
+			select {
+			case <-c.done:
+				report.Done = true
+				progress <- report
+				return
+			default:
+			}
+
+			end := start + batchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+
+			report.Updated += c.updateBatch(keys[start:end], isUpdated, update, duration)
+			report.Processed = end
+			report.Done = end == len(keys)
+			progress <- report
+		}
+
+		if len(keys) == 0 {
+			report.Done = true
+			progress <- report
+		}
+	}()
+
+	return progress
+}
+
+func finishedProgress() <-chan Progress {
+	progress := make(chan Progress, 1)
+	progress <- Progress{Done: true}
+	close(progress)
+
+	return progress
+}
+
+func (c *Cache[K, V]) updateBatch(keys []K, isUpdated func(v V) bool, update func(v V), duration time.Duration) (updated int) {
+	c.lock(opUpdate)
+	defer c.unlock()
+
+	c.drainHits()
+
+	for _, key := range keys {
+		item, found := c.items[key]
+		if !found || c.isExpired(item) || !isUpdated(item.Value) {
+			continue
+		}
+
+		update(item.Value)
+		c.seal(item)
+		c.audit("", auditUpdate, key, &item.Value)
+		c.setExp(item, duration)
+		c.upgradeItem(item, key)
+		c.publish(key, item)
+		updated++
+	}
+
+	return updated
+}
@@ -0,0 +1,39 @@
+package lfu
+
+// PeekVictims returns up to n keys in the order capacity eviction would
+// remove them: lowest frequency first and, within a frequency, least
+// recently used first, or as WithTieBreak selects; under TieBreakRandom the
+// order within a frequency is only one possibility. Expired entries are
+// reclaimed before any eviction and are not listed. Hits buffered by Get
+// are applied first, as any locked operation applies them, so the order
+// accounts for every Get that has returned; nothing else is modified.
+// Caches using WithEvictionPolicy or WithWTinyLFU return nil.
+func (c *Cache[K, V]) PeekVictims(n int) []K {
+	if c.usable() != nil || n <= 0 {
+		return nil
+	}
+
+	c.lock(opStats)
+	defer c.unlock()
+
+	c.drainHits()
+
+	if c.policy != nil {
+		return nil
+	}
+
+	victims := make([]K, 0, n)
+	for b := c.lowest; b != nil; b = b.next {
+		for _, item := range c.ordered(b) {
+			if c.isExpired(item) {
+				continue
+			}
+			victims = append(victims, item.key)
+			if len(victims) == n {
+				return victims
+			}
+		}
+	}
+
+	return victims
+}
@@ -0,0 +1,31 @@
+package lfu_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/grrrance/lfu-in-memory/lfu"
+)
+
+func TestPeekVictimsAppliesBufferedHits(t *testing.T) {
+	c, _ := newClockCache(t, 4, 0, lfu.WithReadMostly())
+
+	c.Set("a", 1, time.Hour)
+	c.Set("b", 2, time.Hour)
+	c.Get("a")
+	c.Get("a")
+
+	if got, want := c.PeekVictims(2), []string{"b", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("PeekVictims = %v, want %v", got, want)
+	}
+}
+
+func TestPeekVictimsUnderWTinyLFU(t *testing.T) {
+	c, _ := newClockCache(t, 4, 0, lfu.WithWTinyLFU())
+
+	c.Set("a", 1, time.Hour)
+	if got := c.PeekVictims(1); got != nil {
+		t.Fatalf("PeekVictims = %v, want nil", got)
+	}
+}
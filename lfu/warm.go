@@ -0,0 +1,181 @@
+package lfu
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	warmContentType = "application/x-lfu-warm"
+	warmVersion     = 1
+	warmBatch       = 256
+)
+
+type warmHeader struct {
+	Version int
+}
+
+type warmEntry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	TTL       time.Duration
+	Frequency uint64
+}
+
+// WarmHandler serves a snapshot of the cache's live entries, with their
+// remaining TTLs and frequencies, for WarmFromPeer on another instance.
+// Entries are gob-encoded, so concrete types stored in interface values
+// must be registered with gob.Register on both sides.
+func (c *Cache[K, V]) WarmHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		if err := c.usable(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		entries := c.warmSnapshot()
+
+		w.Header().Set("Content-Type", warmContentType)
+		out := bufio.NewWriter(w)
+		enc := gob.NewEncoder(out)
+		if err := enc.Encode(warmHeader{Version: warmVersion}); err != nil {
+			return
+		}
+		for i := range entries {
+			if err := enc.Encode(&entries[i]); err != nil {
+				return
+			}
+		}
+		out.Flush()
+	})
+}
+
+func (c *Cache[K, V]) warmSnapshot() []warmEntry[K, V] {
+	c.lock(opStats)
+	defer c.unlock()
+
+	c.drainHits()
+
+	entries := make([]warmEntry[K, V], 0, len(c.items))
+	for key, item := range c.items {
+		if ttl := c.remaining(item); ttl > 0 {
+			entries = append(entries, warmEntry[K, V]{
+				Key:       key,
+				Value:     item.Value,
+				TTL:       ttl,
+				Frequency: item.Frequency,
+			})
+		}
+	}
+
+	return entries
+}
+
+// WarmFromPeer loads the snapshot served by a peer's WarmHandler at url,
+// storing every entry with its remaining TTL and raising its frequency to
+// the peer's. Entries are applied in batches as they arrive, so on error
+// the cache keeps those already loaded. It returns the number of entries
+// stored.
+func (c *Cache[K, V]) WarmFromPeer(ctx context.Context, url string) (int, error) {
+	if err := c.writable(); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Warm peer returned %s", resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != warmContentType {
+		return 0, fmt.Errorf("Warm peer returned content type %q", ct)
+	}
+
+	dec := gob.NewDecoder(bufio.NewReader(resp.Body))
+	var header warmHeader
+	if err := dec.Decode(&header); err != nil {
+		return 0, err
+	}
+	if header.Version != warmVersion {
+		return 0, fmt.Errorf("Unsupported warm snapshot version %d", header.Version)
+	}
+
+	var stored int
+	batch := make([]warmEntry[K, V], 0, warmBatch)
+	for {
+		var entry warmEntry[K, V]
+		err := dec.Decode(&entry)
+		if err == nil {
+			batch = append(batch, entry)
+			if len(batch) < warmBatch {
+				continue
+			}
+		}
+
+		n, werr := c.warm(batch)
+		stored += n
+		batch = batch[:0]
+
+		switch {
+		case werr != nil:
+			return stored, werr
+		case errors.Is(err, io.EOF):
+			return stored, nil
+		case err != nil:
+			return stored, err
+		}
+	}
+}
+
+func (c *Cache[K, V]) warm(entries []warmEntry[K, V]) (int, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	c.lock(opSet)
+	defer c.unlock()
+
+	if err := c.usable(); err != nil {
+		return 0, err
+	}
+	c.drainHits()
+
+	var stored int
+	for _, entry := range entries {
+		if entry.TTL <= 0 {
+			continue
+		}
+		switch c.put("", entry.Key, entry.Value, entry.TTL) {
+		case SetStored, SetReplaced, SetUnchanged:
+			stored++
+		default:
+			continue
+		}
+
+		if item, ok := c.items[entry.Key]; ok && entry.Frequency > item.Frequency {
+			item.Frequency = entry.Frequency
+			c.move(item)
+		}
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,29 @@
+package lfu
+
+// WithHighWatermark calls fn once when the cache's cost first reaches
+// fraction of its capacity, before eviction has to start, and again each
+// time it climbs back after falling below. fn receives the cost and the
+// capacity and runs after the cache lock is released. Evictions do not
+// count as falling below, so a full cache does not fire it on every Set.
+// fraction must be in (0, 1].
+func WithHighWatermark(fraction float64, fn func(cost, capacity int)) Option {
+	return func(c *settings) {
+		if fn != nil && fraction > 0 && fraction <= 1 {
+			c.highWatermark = fraction
+			c.onHighWatermark = fn
+		}
+	}
+}
+
+func (c *Cache[K, V]) checkWatermark() {
+	if c.onHighWatermark == nil {
+		return
+	}
+
+	above := float64(c.cost) >= c.highWatermark*float64(c.size)
+	if above && !c.aboveWatermark {
+		fn, cost, size := c.onHighWatermark, c.cost, c.size
+		c.afterUnlock(func() { fn(cost, size) })
+	}
+	c.aboveWatermark = above
+}
@@ -0,0 +1,225 @@
+package lfu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	EventEvict  = "evict"
+	EventExpire = "expire"
+	EventHotKey = "hot_key"
+	// EventDelete and EventSet report the two halves of a Rename: the old
+	// key leaving and the new key arriving.
+	EventDelete = "delete"
+	EventSet    = "set"
+)
+
+// WebhookEvent is one entry of the JSON array posted to a webhook.
+type WebhookEvent struct {
+	Type      string    `json:"type"`
+	Key       string    `json:"key"`
+	Frequency uint64    `json:"frequency,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// WebhookConfig configures WithWebhook. Zero fields take the defaults
+// below; HotKeyFrequency 0 disables hot-key events.
+type WebhookConfig struct {
+	URL             string
+	Client          *http.Client
+	BatchSize       int
+	FlushInterval   time.Duration
+	QueueSize       int
+	MaxRetries      int
+	Backoff         time.Duration
+	HotKeyFrequency uint64
+	OnError         func(err error, batch []WebhookEvent)
+}
+
+const (
+	defaultWebhookBatch    = 100
+	defaultWebhookInterval = time.Second
+	defaultWebhookQueue    = 4096
+	defaultWebhookRetries  = 3
+	defaultWebhookBackoff  = 100 * time.Millisecond
+	defaultWebhookTimeout  = 10 * time.Second
+)
+
+type webhook struct {
+	WebhookConfig
+	events chan WebhookEvent
+}
+
+// WithWebhook posts eviction, expiration, hot-key and rename events in
+// batches to cfg.URL from a background goroutine. A batch is sent when it is
+// full or FlushInterval has passed; failed posts are retried with doubling
+// backoff and then handed to OnError. Events are dropped while the queue is
+// full. Shutdown sends whatever is still queued before returning.
+func WithWebhook(cfg WebhookConfig) Option {
+	return func(c *settings) {
+		if cfg.URL == "" {
+			return
+		}
+		if cfg.Client == nil {
+			cfg.Client = &http.Client{Timeout: defaultWebhookTimeout}
+		}
+		if cfg.BatchSize <= 0 {
+			cfg.BatchSize = defaultWebhookBatch
+		}
+		if cfg.FlushInterval <= 0 {
+			cfg.FlushInterval = defaultWebhookInterval
+		}
+		if cfg.QueueSize <= 0 {
+			cfg.QueueSize = defaultWebhookQueue
+		}
+		if cfg.MaxRetries < 0 {
+			cfg.MaxRetries = 0
+		} else if cfg.MaxRetries == 0 {
+			cfg.MaxRetries = defaultWebhookRetries
+		}
+		if cfg.Backoff <= 0 {
+			cfg.Backoff = defaultWebhookBackoff
+		}
+
+		c.webhook = &webhook{
+			WebhookConfig: cfg,
+			events:        make(chan WebhookEvent, cfg.QueueSize),
+		}
+	}
+}
+
+func (c *Cache[K, V]) emit(kind, key string, freq uint64) {
+	event := WebhookEvent{Type: kind, Key: key, Frequency: freq, Time: c.clock.Now()}
+	c.notify(event)
+
+	if c.webhook == nil {
+		return
+	}
+
+	select {
+	case c.webhook.events <- event:
+	default:
+	}
+}
+
+func (c *Cache[K, V]) emitRemoval(item *Item[K, V], key K, reason EvictionReason) {
+	if c.webhook == nil && len(c.subscriptions) == 0 {
+		return
+	}
+
+	switch reason {
+	case ReasonCapacity, ReasonCold:
+		c.emit(EventEvict, keyText(key), item.Frequency)
+	case ReasonExpired:
+		c.emit(EventExpire, keyText(key), item.Frequency)
+	}
+}
+
+func (c *Cache[K, V]) emitRename(item *Item[K, V], oldKey, newKey K) {
+	if c.webhook == nil && len(c.subscriptions) == 0 {
+		return
+	}
+
+	c.emit(EventDelete, keyText(oldKey), item.Frequency)
+	c.emit(EventSet, keyText(newKey), item.Frequency)
+}
+
+func (c *Cache[K, V]) emitHot(key K, freq uint64) {
+	if c.webhook != nil && freq == c.webhook.HotKeyFrequency {
+		c.emit(EventHotKey, keyText(key), freq)
+	}
+}
+
+func (c *Cache[K, V]) dispatchWebhook(ticker Ticker) {
+	defer c.workers.Done()
+	defer ticker.Stop()
+
+	w := c.webhook
+	batch := make([]WebhookEvent, 0, w.BatchSize)
+	for {
+		select {
+		case ev := <-w.events:
+			if batch = append(batch, ev); len(batch) < w.BatchSize {
+				continue
+			}
+		case <-ticker.C():
+			if len(batch) > 0 {
+				c.sendWebhook(batch)
+				batch = batch[:0]
+			}
+			ticker.Done()
+			continue
+		case <-c.done:
+			for {
+				select {
+				case ev := <-w.events:
+					if batch = append(batch, ev); len(batch) == w.BatchSize {
+						c.sendWebhook(batch)
+						batch = batch[:0]
+					}
+				default:
+					if len(batch) > 0 {
+						c.sendWebhook(batch)
+					}
+					return
+				}
+			}
+		}
+
+		c.sendWebhook(batch)
+		batch = batch[:0]
+	}
+}
+
+func (c *Cache[K, V]) sendWebhook(batch []WebhookEvent) {
+	w := c.webhook
+	body, err := json.Marshal(batch)
+	if err != nil {
+		w.fail(err, batch)
+		return
+	}
+
+	backoff := w.Backoff
+	for attempt := 0; ; attempt++ {
+		if err = w.post(body); err == nil {
+			return
+		}
+		if attempt == w.MaxRetries {
+			break
+		}
+		<-c.newTimer(backoff).C()
+		backoff *= 2
+	}
+
+	w.fail(err, batch)
+}
+
+func (w *webhook) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (w *webhook) fail(err error, batch []WebhookEvent) {
+	if w.OnError != nil {
+		w.OnError(err, append([]WebhookEvent(nil), batch...))
+	}
+}
@@ -0,0 +1,154 @@
+package lfu
+
+import "container/list"
+
+const (
+	windowPercent    = 1
+	protectedPercent = 80
+)
+
+// WithWTinyLFU evicts by W-TinyLFU instead of plain LFU: new keys enter a
+// small LRU window, about 1% of the capacity, and leave it for a segmented
+// LRU main region only if they have been used more often recently than the
+// main region's victim, as counted by a count-min sketch. Main entries are
+// on probation until accessed again, then protected, with up to 80% of the
+// main region protected. Regions are sized in entries, not cost. It
+// replaces any WithEvictionPolicy.
+func WithWTinyLFU() Option {
+	return func(c *settings) {
+		c.wTinyLFU = true
+	}
+}
+
+type region int
+
+const (
+	regionWindow region = iota
+	regionCandidate
+	regionProbation
+	regionProtected
+)
+
+type wtEntry[K comparable] struct {
+	key    K
+	region region
+}
+
+// wTinyLFU is the W-TinyLFU EvictionPolicy. Keys leaving the window move to
+// probation while the main region has room; otherwise they wait as
+// candidates until the next eviction, which removes either the oldest
+// candidate or the main victim, whichever is less frequent. A candidate
+// that survives moves to probation.
+type wTinyLFU[K comparable] struct {
+	sketch       *sketch
+	lists        [regionProtected + 1]list.List
+	elements     map[K]*list.Element
+	windowCap    int
+	mainCap      int
+	protectedCap int
+}
+
+func newWTinyLFU[K comparable](size int) *wTinyLFU[K] {
+	window := max(1, size*windowPercent/100)
+
+	return &wTinyLFU[K]{
+		sketch:       newSketch(size),
+		elements:     make(map[K]*list.Element),
+		windowCap:    window,
+		mainCap:      size - window,
+		protectedCap: (size - window) * protectedPercent / 100,
+	}
+}
+
+func (p *wTinyLFU[K]) OnAdd(key K) {
+	p.sketch.add(hashKey(p.sketch.seed, key))
+	if element, ok := p.elements[key]; ok {
+		p.moveTo(element, regionWindow)
+	} else {
+		p.elements[key] = p.lists[regionWindow].PushBack(&wtEntry[K]{key: key, region: regionWindow})
+	}
+
+	for p.lists[regionWindow].Len() > p.windowCap {
+		to := regionCandidate
+		if p.lists[regionProbation].Len()+p.lists[regionProtected].Len() < p.mainCap {
+			to = regionProbation
+		}
+		p.moveTo(p.lists[regionWindow].Front(), to)
+	}
+}
+
+func (p *wTinyLFU[K]) OnAccess(key K) {
+	p.sketch.add(hashKey(p.sketch.seed, key))
+	element, ok := p.elements[key]
+	if !ok {
+		return
+	}
+
+	switch element.Value.(*wtEntry[K]).region {
+	case regionWindow, regionProtected, regionCandidate:
+		p.lists[element.Value.(*wtEntry[K]).region].MoveToBack(element)
+	case regionProbation:
+		p.moveTo(element, regionProtected)
+		for p.lists[regionProtected].Len() > p.protectedCap {
+			p.moveTo(p.lists[regionProtected].Front(), regionProbation)
+		}
+	}
+}
+
+func (p *wTinyLFU[K]) OnRemove(key K) {
+	element, ok := p.elements[key]
+	if !ok {
+		return
+	}
+
+	entry := element.Value.(*wtEntry[K])
+	p.lists[entry.region].Remove(element)
+	delete(p.elements, key)
+
+	if entry.region == regionProbation || entry.region == regionProtected {
+		if candidate := p.lists[regionCandidate].Front(); candidate != nil {
+			p.moveTo(candidate, regionProbation)
+		}
+	}
+}
+
+func (p *wTinyLFU[K]) Victim(exclude K) (K, bool) {
+	candidate, hasCandidate := p.first(exclude, regionCandidate)
+	victim, hasVictim := p.first(exclude, regionProbation, regionProtected)
+
+	switch {
+	case hasCandidate && hasVictim:
+		if p.sketch.estimate(hashKey(p.sketch.seed, candidate)) > p.sketch.estimate(hashKey(p.sketch.seed, victim)) {
+			return victim, true
+		}
+		return candidate, true
+	case hasCandidate:
+		return candidate, true
+	case hasVictim:
+		return victim, true
+	}
+
+	return p.first(exclude, regionWindow)
+}
+
+// first returns the least recently used key of the first of regions that
+// has one other than exclude.
+func (p *wTinyLFU[K]) first(exclude K, regions ...region) (K, bool) {
+	for _, r := range regions {
+		for element := p.lists[r].Front(); element != nil; element = element.Next() {
+			if key := element.Value.(*wtEntry[K]).key; key != exclude {
+				return key, true
+			}
+		}
+	}
+
+	var zero K
+	return zero, false
+}
+
+func (p *wTinyLFU[K]) moveTo(element *list.Element, to region) {
+	entry := element.Value.(*wtEntry[K])
+	p.lists[entry.region].Remove(element)
+	entry.region = to
+	p.elements[entry.key] = p.lists[to].PushBack(entry)
+}